@@ -1,7 +0,0 @@
-package node
-
-type Node struct {
-	Id int
-}
-
-type NodeOrderedSet []Node
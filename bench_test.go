@@ -0,0 +1,70 @@
+package gator_test
+
+import (
+	"testing"
+
+	"github.com/ShaleApps/gator"
+)
+
+var benchTokens = []struct {
+	token string
+	f     gator.Func
+	value interface{}
+}{
+	{"nonzero", gator.Nonzero(), "gator"},
+	{"eq", gator.Eq("1"), 1},
+	{"email", gator.Email(), "gator@example.com"},
+	{"hexcolor", gator.HexColor(), "#ffffff"},
+	{"url", gator.URL(), "https://www.example.com/gator"},
+	{"ip", gator.IP(), "192.168.0.1"},
+	{"alpha", gator.Alpha(), "gator"},
+	{"num", gator.Num(), "12345"},
+	{"alphanum", gator.AlphaNum(), "gator12345"},
+	{"matches", gator.Matches(`^\d{5}$`), "12345"},
+	{"lat", gator.Lat(), 45.5},
+	{"lon", gator.Lon(), -120.5},
+	{"gt", gator.Gt(18.0), 21},
+	{"gte", gator.Gte(18.0), 18},
+	{"lt", gator.Lt(18.0), 17.5},
+	{"lte", gator.Lte(18.0), uint(18)},
+	{"in", gator.In([]interface{}{"a", "b", "c"}), "c"},
+	{"notin", gator.NotIn([]interface{}{"a", "b", "c"}), "d"},
+	{"len", gator.Len(5), "gator"},
+	{"minlen", gator.MinLen(5), "gator"},
+	{"maxlen", gator.MaxLen(5), "gator"},
+	{"each", gator.Each(gator.Gt(18.0), gator.Lt(35.0)), []int{19, 20, 34}},
+}
+
+func BenchmarkTokens(b *testing.B) {
+	for _, bt := range benchTokens {
+		b.Run(bt.token, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := bt.f("Field", bt.value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewStruct(b *testing.B) {
+	b.ReportAllocs()
+	src := &testStruct3{"http://www.google.com", "logan12345"}
+	for i := 0; i < b.N; i++ {
+		if err := gator.NewStruct(src).Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPlan(b *testing.B) {
+	b.ReportAllocs()
+	p := gator.MustCompile(testStruct3{})
+	src := &testStruct3{"http://www.google.com", "logan12345"}
+	for i := 0; i < b.N; i++ {
+		if err := p.Validate(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package gator
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// builtinCatalogs holds the messages of the built-in tokens.  en.json
+// provides the Registry's own messages, the others are Catalogs.
+//
+//go:embed catalogs/*.json
+var builtinCatalogs embed.FS
+
+// A Catalog holds the messages of tokens in one locale.  Messages are
+// text/templates executed with a MessageData.  They may have plural
+// forms, which are chosen by the numerical argument of the token, e.g.
+// the 5 of minlen(5).
+type Catalog struct {
+	locale   string
+	messages map[string]messageForms
+}
+
+// NewCatalog returns an empty Catalog for locale, e.g. "es" or "es-MX".
+func NewCatalog(locale string) *Catalog {
+	return &Catalog{locale: normalizeLocale(locale), messages: map[string]messageForms{}}
+}
+
+// Locale returns the locale of the Catalog.
+func (c *Catalog) Locale() string {
+	return c.locale
+}
+
+// Set sets the message of token.
+func (c *Catalog) Set(token, text string) error {
+	return c.SetPlural(token, map[string]string{"other": text})
+}
+
+// SetPlural sets the plural forms of the message of token keyed by CLDR
+// plural category, e.g. "one" and "other".  An "other" form is
+// required.
+func (c *Catalog) SetPlural(token string, forms map[string]string) error {
+	m, err := parseForms(token, forms)
+	if err != nil {
+		return err
+	}
+	c.messages[token] = m
+	return nil
+}
+
+// ParseCatalog parses a Catalog from a JSON or TOML file named name.
+// The format is chosen by the extension of name.  Both formats hold a
+// locale and a table of messages keyed by token.  A message is either
+// a string or a table of plural forms:
+//
+//	{"locale": "es", "messages": {
+//		"nonzero": "{{.Field}} es obligatorio.",
+//		"minlen": {"one": "...", "other": "..."}
+//	}}
+//
+//	locale = "es"
+//	[messages]
+//	nonzero = "{{.Field}} es obligatorio."
+//	[messages.minlen]
+//	one = "..."
+//	other = "..."
+//
+// Only the subset of TOML shown above is supported.
+func ParseCatalog(name string, data []byte) (*Catalog, error) {
+	var f catalogFile
+	var err error
+	switch path.Ext(name) {
+	case ".json":
+		err = f.parseJSON(data)
+	case ".toml":
+		err = f.parseTOML(data)
+	default:
+		err = fmt.Errorf("unknown format %q", path.Ext(name))
+	}
+	if err == nil && f.locale == "" {
+		err = fmt.Errorf("no locale")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gator: couldn't parse catalog %s - %s", name, err)
+	}
+	c := NewCatalog(f.locale)
+	for token, forms := range f.messages {
+		if err := c.SetPlural(token, forms); err != nil {
+			return nil, fmt.Errorf("gator: couldn't parse catalog %s - %s", name, err)
+		}
+	}
+	return c, nil
+}
+
+// LoadCatalogs parses every JSON and TOML file in the root of fsys,
+// which is usually an embed.FS.
+func LoadCatalogs(fsys fs.FS) ([]*Catalog, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	catalogs := []*Catalog{}
+	for _, e := range entries {
+		if e.IsDir() || (path.Ext(e.Name()) != ".json" && path.Ext(e.Name()) != ".toml") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		c, err := ParseCatalog(e.Name(), data)
+		if err != nil {
+			return nil, err
+		}
+		catalogs = append(catalogs, c)
+	}
+	return catalogs, nil
+}
+
+// catalogFile is the contents of a catalog file.  Messages without
+// plural forms only have an "other" form.
+type catalogFile struct {
+	locale   string
+	messages map[string]map[string]string
+}
+
+func (f *catalogFile) parseJSON(data []byte) error {
+	doc := struct {
+		Locale   string                     `json:"locale"`
+		Messages map[string]json.RawMessage `json:"messages"`
+	}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	f.locale = doc.Locale
+	f.messages = map[string]map[string]string{}
+	for token, raw := range doc.Messages {
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			f.messages[token] = map[string]string{"other": text}
+			continue
+		}
+		forms := map[string]string{}
+		if err := json.Unmarshal(raw, &forms); err != nil {
+			return fmt.Errorf("message for %s must be a string or an object of strings", token)
+		}
+		f.messages[token] = forms
+	}
+	return nil
+}
+
+func (f *catalogFile) parseTOML(data []byte) error {
+	f.messages = map[string]map[string]string{}
+	table := ""
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		switch {
+		case text == "" || text[0] == '#':
+			continue
+		case text[0] == '[':
+			if !strings.HasSuffix(text, "]") {
+				return fmt.Errorf("line %d: unclosed table header", line)
+			}
+			table = strings.TrimSpace(text[1 : len(text)-1])
+			if table != "messages" && !strings.HasPrefix(table, "messages.") {
+				return fmt.Errorf("line %d: unknown table %q", line, table)
+			}
+			continue
+		}
+		i := strings.IndexByte(text, '=')
+		if i < 0 {
+			return fmt.Errorf("line %d: expected key = value", line)
+		}
+		key := strings.TrimSpace(text[:i])
+		value, err := tomlString(strings.TrimSpace(text[i+1:]))
+		if err != nil {
+			return fmt.Errorf("line %d: %s", line, err)
+		}
+		switch {
+		case table == "" && key == "locale":
+			f.locale = value
+		case table == "":
+			return fmt.Errorf("line %d: unknown key %q", line, key)
+		case table == "messages":
+			f.messages[key] = map[string]string{"other": value}
+		default:
+			token := strings.TrimPrefix(table, "messages.")
+			if f.messages[token] == nil {
+				f.messages[token] = map[string]string{}
+			}
+			f.messages[token][key] = value
+		}
+	}
+	return s.Err()
+}
+
+// tomlString decodes a TOML basic or literal string.
+func tomlString(s string) (string, error) {
+	switch {
+	case len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"':
+		return strconv.Unquote(s)
+	case len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'':
+		return s[1 : len(s)-1], nil
+	}
+	return "", fmt.Errorf("expected a string, got %s", s)
+}
+
+// normalizeLocale lowercases locale and separates its subtags with
+// hyphens, e.g. "es_MX" becomes "es-mx".
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+// baseLocale returns the language of locale, e.g. "es" for "es-MX".
+func baseLocale(locale string) string {
+	locale = normalizeLocale(locale)
+	if i := strings.IndexByte(locale, '-'); i >= 0 {
+		return locale[:i]
+	}
+	return locale
+}
@@ -0,0 +1,427 @@
+package gator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// arity describes the arguments a struct tag token accepts.
+type arity int
+
+const (
+	// anyArgs is not checked.  It is used by RegisterStructTagToken.
+	anyArgs arity = iota
+	noArgs
+	oneArg
+	listArgs
+)
+
+// tokenFunc creates a checkFunc from a parsed struct tag token.
+type tokenFunc func(c *compiler, n *tokenNode) (checkFunc, error)
+
+// tokenDef is a registered struct tag token.
+type tokenDef struct {
+	arity arity
+	f     tokenFunc
+	// lookup is set for tokens that call a Resolver.  Their rules are
+	// run concurrently once the value has been walked.
+	lookup bool
+}
+
+// fieldContext describes the field a rule is validating.
+type fieldContext struct {
+	name  string
+	path  Path
+	value interface{}
+	// parent is the struct holding the field and root is the struct
+	// being validated.  They are invalid for Fields created with
+	// NewField, as is field.
+	parent reflect.Value
+	root   reflect.Value
+	field  reflect.StructField
+	// old is the previous value of the field when validating a change.
+	// hasOld is set if it is available.
+	old    interface{}
+	hasOld bool
+	// reg and locale are used to find the messages of failed rules.
+	reg    *Registry
+	locale string
+	// ctx is the context passed to ValidateContext.  It is nil for
+	// Validate.
+	ctx context.Context
+	// lookup configures the Resolver of lookup tokens.  It is nil if
+	// there is none.
+	lookup *lookupConfig
+}
+
+// context returns the context of the validation.
+func (fc *fieldContext) context() context.Context {
+	if fc.ctx == nil {
+		return context.Background()
+	}
+	return fc.ctx
+}
+
+// checkFunc is the compiled form of a token.  Unlike a Func it can see
+// the struct holding the field.
+type checkFunc func(fc *fieldContext) error
+
+// funcCheck returns a checkFunc that calls f.
+func funcCheck(f Func) checkFunc {
+	return func(fc *fieldContext) error {
+		return f(fc.name, fc.value)
+	}
+}
+
+// fieldFuncCheck returns a checkFunc that calls f with the exported
+// form of the field's context.
+func fieldFuncCheck(token string, args []string, f FieldFunc) checkFunc {
+	return func(fc *fieldContext) error {
+		return f(&FieldContext{
+			Name:     fc.name,
+			Path:     fc.path,
+			Value:    fc.value,
+			Parent:   fc.parent,
+			Root:     fc.root,
+			Field:    fc.field,
+			Token:    token,
+			Args:     args,
+			Registry: fc.reg,
+			ctx:      fc.ctx,
+		})
+	}
+}
+
+// contextFuncCheck returns a checkFunc that calls f with the context of
+// the validation.
+func contextFuncCheck(f ContextFunc) checkFunc {
+	return func(fc *fieldContext) error {
+		return f(fc.context(), fc.name, fc.value)
+	}
+}
+
+// rule is a checkFunc paired with the struct tag token and argument it
+// was created from.
+type rule struct {
+	token string
+	arg   string
+	args  []string
+	check checkFunc
+	// groups holds the validation groups the rule belongs to.
+	groups []string
+	// fieldMsg is the message of the field the rule belongs to and
+	// tokenMsg is the message of the token.  See rule.message.
+	fieldMsg *template.Template
+	tokenMsg messageForms
+	// lookup is set if the rule's token calls a Resolver.
+	lookup bool
+}
+
+// in reports whether the rule belongs to any of groups.
+func (r rule) in(groups []string) bool {
+	for _, g := range r.groups {
+		for _, selected := range groups {
+			if g == selected {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// errSkip is returned by checkFuncs, such as omitempty's, to stop the
+// rules that follow them from running.
+var errSkip = errors.New("gator: skip remaining rules")
+
+// run calls the rule's checkFunc and wraps any error it returns, or
+// panic it raises, in a *FieldError.  skip is set if the rules that
+// follow shouldn't be run.
+func (r rule) run(fc *fieldContext) (fErr *FieldError, skip bool) {
+	defer func() {
+		if p := recover(); p != nil {
+			fErr = r.fieldError(fc, fmt.Errorf("gator: %s panicked while validating %s - %v", r.funcName(), fc.name, p))
+		}
+	}()
+	switch err := r.check(fc); err {
+	case nil:
+		return nil, false
+	case errSkip:
+		return nil, true
+	default:
+		return r.fieldError(fc, r.message(fc, err)), false
+	}
+}
+
+func (r rule) funcName() string {
+	if r.token == "" {
+		return "Func"
+	}
+	return "token " + r.token
+}
+
+func (r rule) fieldError(fc *fieldContext, err error) *FieldError {
+	return &FieldError{
+		Field:  fc.name,
+		Path:   fc.path,
+		Token:  r.token,
+		Arg:    r.arg,
+		Code:   errorCode(r.token),
+		Params: r.params(err),
+		Value:  fc.value,
+		Err:    err,
+	}
+}
+
+// errorCode returns the Code of FieldErrors of token.
+func errorCode(token string) string {
+	if token == "" {
+		return "gator.invalid"
+	}
+	return "gator." + token
+}
+
+// params returns the Params of a FieldError of the rule failing with
+// err.
+func (r rule) params(err error) map[string]interface{} {
+	m := map[string]interface{}{}
+	switch {
+	case len(r.args) == 1:
+		m["arg"] = paramValue(r.args[0])
+	case len(r.args) > 1:
+		args := make([]interface{}, len(r.args))
+		for i, arg := range r.args {
+			args[i] = paramValue(arg)
+		}
+		m["args"] = args
+	}
+	var cErr *ChangeError
+	if errors.As(err, &cErr) {
+		m["from"], m["to"] = cErr.From, cErr.To
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// paramValue returns arg as a number if it is one.
+func paramValue(arg string) interface{} {
+	n, ok := parseNumber(arg)
+	if !ok {
+		return arg
+	}
+	switch n.kind {
+	case intKind:
+		return n.i
+	case uintKind:
+		return n.u
+	}
+	return n.f
+}
+
+// compiler creates rules from gator tags and records the problems it
+// finds as TagErrors.  Syntax errors are always recorded.  Unknown
+// tokens, the wrong number of arguments and unparsable arguments are
+// only recorded in strict mode, otherwise unknown tokens are ignored
+// and unparsable arguments produce a rule that always fails.
+type compiler struct {
+	reg    *Registry
+	strict bool
+	// typ is the struct type whose fields are being compiled.
+	typ   reflect.Type
+	field string
+	tag   string
+	errs  TagErrors
+}
+
+// rules parses the tag belonging to field and returns its rules.
+// Rules that aren't given a group belong to DefaultGroup.  msg is the
+// field's message template, if any.
+func (c *compiler) rules(field, tag, msg string) []rule {
+	c.field = field
+	c.tag = tag
+	groups, err := parseGroups(tag)
+	if err != nil {
+		c.addError(err.(*TagError))
+		return nil
+	}
+	var fieldMsg *template.Template
+	if msg != "" {
+		if fieldMsg, err = parseMessage(field, msg); err != nil {
+			c.errs = append(c.errs, &TagError{Field: field, Tag: msg, Col: 1, Msg: "invalid message - " + err.Error()})
+		}
+	}
+	rules := []rule{}
+	for _, g := range groups {
+		names := g.names
+		if len(names) == 0 {
+			names = []string{DefaultGroup}
+		}
+		for _, r := range c.compile(g.expr) {
+			r.groups = names
+			r.fieldMsg = fieldMsg
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// compile creates rules from nd.  Tokens joined by pipes become
+// separate rules while alternatives and negations become a single rule.
+func (c *compiler) compile(nd node) []rule {
+	switch n := nd.(type) {
+	case *andNode:
+		rules := []rule{}
+		for _, child := range n.nodes {
+			rules = append(rules, c.compile(child)...)
+		}
+		return rules
+	case *orNode:
+		names := []string{}
+		alts := []checkFunc{}
+		for _, child := range n.nodes {
+			names = append(names, child.source())
+			alts = append(alts, rulesCheck(c.compile(child)))
+		}
+		return []rule{{token: "or", arg: n.text, args: names, check: orCheck(names, alts)}}
+	case *notNode:
+		check := notCheck(rulesCheck(c.compile(n.node)))
+		arg := n.node.source()
+		return []rule{{token: "not", arg: arg, args: []string{arg}, check: check, tokenMsg: c.reg.message("not")}}
+	case *tokenNode:
+		return c.compileToken(n)
+	}
+	return nil
+}
+
+// compileToken creates the rule for n.  No rule is returned if n can't
+// be compiled.
+func (c *compiler) compileToken(n *tokenNode) []rule {
+	def, ok := c.reg.lookup(n.name)
+	if !ok {
+		if c.strict {
+			c.errorf(n.col, "unknown token %q", n.name)
+		}
+		return nil
+	}
+	if c.strict && !c.checkArity(def.arity, n) {
+		return nil
+	}
+	check, err := def.f(c, n)
+	if tErr, ok := err.(*TagError); ok {
+		c.addError(tErr)
+		return nil
+	}
+	if err != nil {
+		if c.strict {
+			c.errorf(n.col, "invalid argument for %q - %s", n.name, err)
+			return nil
+		}
+		check = funcCheck(textErrorFunc(n.raw, err))
+	}
+	return []rule{{token: n.name, arg: n.arg(), args: n.args, check: check, tokenMsg: c.reg.message(n.name), lookup: def.lookup}}
+}
+
+// rulesCheck returns a checkFunc that runs each of rules and returns
+// the first failure as a *FieldError with the rule's message and Code.
+func rulesCheck(rules []rule) checkFunc {
+	return func(fc *fieldContext) error {
+		for _, r := range rules {
+			fErr, skip := r.run(fc)
+			switch {
+			case fErr != nil:
+				return fErr
+			case skip:
+				return nil
+			}
+		}
+		return nil
+	}
+}
+
+func (c *compiler) checkArity(a arity, n *tokenNode) bool {
+	switch {
+	case a == noArgs && n.paren:
+		c.errorf(n.col, "%q takes no arguments", n.name)
+	case a == oneArg && len(n.args) == 0:
+		c.errorf(n.col, "%q requires an argument", n.name)
+	case a == listArgs && len(n.args) == 0:
+		c.errorf(n.col, "%q requires at least one argument", n.name)
+	default:
+		return true
+	}
+	return false
+}
+
+func (c *compiler) errorf(col int, format string, a ...interface{}) {
+	c.addError(&TagError{Col: col, Msg: fmt.Sprintf(format, a...)})
+}
+
+func (c *compiler) addError(err *TagError) {
+	err.Field = c.field
+	err.Tag = c.tag
+	c.errs = append(c.errs, err)
+}
+
+// fieldRef refers to a field of the struct being compiled by a dotted
+// path such as "Window.Start".
+type fieldRef struct {
+	path string
+	// index holds the reflect index of each field in the path.
+	index [][]int
+}
+
+// fieldRef resolves path against the struct type being compiled.
+// Pointers to structs are followed and promoted fields can be used.
+func (c *compiler) fieldRef(path string) (fieldRef, error) {
+	ref := fieldRef{path: path}
+	t := c.typ
+	if t == nil {
+		return ref, fmt.Errorf("field %q can't be referenced outside of a struct", path)
+	}
+	for _, name := range strings.Split(path, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return ref, fmt.Errorf("field %q doesn't exist on %s", path, c.typ)
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return ref, fmt.Errorf("field %q doesn't exist on %s", path, c.typ)
+		}
+		ref.index = append(ref.index, f.Index)
+		t = f.Type
+	}
+	return ref, nil
+}
+
+// value returns the referenced field of parent.  It returns false if a
+// nil pointer is found along the path or if the field's value can't be
+// copied.
+func (ref fieldRef) value(parent reflect.Value) (interface{}, bool) {
+	if !parent.IsValid() {
+		return nil, false
+	}
+	v := parent
+	for _, index := range ref.index {
+		for _, i := range index {
+			for v.Kind() == reflect.Ptr {
+				if v.IsNil() {
+					return nil, false
+				}
+				v = v.Elem()
+			}
+			v = v.Field(i)
+		}
+	}
+	c, ok := copyValue(v)
+	if !ok {
+		return nil, false
+	}
+	return c.Interface(), true
+}
@@ -1,7 +1,8 @@
 /*
 package gator (or valigator) is a library that validates structs using struct tags.  Here is a usage example:
 
-    // NOTE: Gator tags will only be recognized on fields that are public.
+    // NOTE: Gator tags will only be recognized on fields that are public
+    // unless the gator.WithUnexported() option is used.
     type BigStruct struct {
         Required        string      `gator:”nonzero”`
         Email           string      `gator:”email”`
@@ -26,6 +27,150 @@ package gator (or valigator) is a library that validates structs using struct ta
         fmt.Println(err)
     }
 
+NewStruct also validates nested structs, non-nil pointers to structs, embedded structs and the structs held in slices, arrays and maps.  Errors name fields by their path from the root struct, e.g. Shipments[3].Origin.Zip.
+
+Tokens are separated by pipes and arguments by commas.  Arguments containing commas, pipes or unbalanced parentheses can be wrapped in single or double quotes, and quoted arguments support backslash escapes:
+
+    type Listing struct {
+        Tags []string `gator:"each( minlen(2) | notin('a,b', 'c|d') )"`
+        Note string   `gator:"matches('^[^|]*$')"`
+    }
+
+Fields holding non-nil pointers are validated by the value they point to, so nonzero fails for a pointer to a zero value.  notnil only requires a pointer to be set.  omitempty skips the tokens that follow it when the value is zero and omitnil skips them only when a pointer is nil:
+
+    type Profile struct {
+        Email   *string `gator:"omitempty | email"`
+        Website string  `gator:"omitempty | url"`
+        Age     *int    `gator:"omitnil | gte(0)"`
+        Count   *int    `gator:"notnil"`
+    }
+
+A pipe means every token must pass.  Alternatives are joined with ||, which binds more loosely than |, tokens are negated with ! or not(...) and parentheses group them.  When no alternative passes the error is an OrError listing each alternative tried.  gator.Or, gator.Not and gator.All combine Funcs the same way:
+
+    type Server struct {
+        Host string `gator:"ip || hostname"`
+        Name string `gator:"minlen(2) | !in(admin, root) | (alpha || matches('^[a-z]+-[a-z]+$'))"`
+    }
+
+    f := gator.Or(gator.IP(), gator.Hostname())
+
+Fields can be compared to other fields of the same struct with eqfield, nefield, gtfield, gtefield, ltfield and ltefield.  Numbers, strings and time.Times are supported and fields of nested structs are referenced by their dotted path:
+
+    type Booking struct {
+        Password        string
+        ConfirmPassword string    `gator:"eqfield(Password)"`
+        StartDate       time.Time
+        EndDate         time.Time `gator:"gtfield(StartDate)"`
+        Window          *Window
+        Pickup          time.Time `gator:"gtefield(Window.Open) | ltefield(Window.Close)"`
+    }
+
+Conditional tokens require a field to be nonzero depending on other fields.  required_if and excluded_if take pairs of fields and values, required_with and required_without take fields:
+
+    type Tender struct {
+        EquipmentType string
+        TrailerNumber string `gator:"required_if(EquipmentType,flatbed)"`
+        Temperature   *int   `gator:"required_unless(EquipmentType,flatbed)"`
+        Tarps         int    `gator:"excluded_if(EquipmentType,reefer)"`
+        Phone         string `gator:"required_without(Email)"`
+        Email         string `gator:"required_without(Phone)"`
+    }
+
+Rules can be assigned to validation groups by prefixing them with group names and separating groups with semicolons.  Rules without a group belong to gator.DefaultGroup, which is the only group validated unless the Groups option selects others:
+
+    type Load struct {
+        ID     int    `gator:"create: eq(0); update: gt(0)"`
+        Email  string `gator:"create: nonzero | email; update: omitempty | email"`
+        Name   string `gator:"maxlen(50); create, update: alphanum"`
+    }
+
+    err := gator.NewStruct(l, gator.Groups("update", gator.DefaultGroup)).Validate()
+
+For partial updates only the fields a client sent need to be valid.  NewMergePatch validates the fields present in a JSON merge patch, matching keys to fields the way encoding/json does, and the OnlyFields option selects fields by path:
+
+    patch := []byte(`{"name": "Gator Freight", "office": {"zip": "70112"}}`)
+    if err := json.Unmarshal(patch, carrier); err != nil {
+        return err
+    }
+    err := gator.NewMergePatch(carrier, patch).Validate()
+
+    err = gator.NewStruct(carrier, gator.OnlyFields("Name", "Office.Zip")).Validate()
+
+Updates can be checked against the previous value with NewChange.  immutable fields can't change, immutable_once_set fields can't change once they are nonzero and transition lists the allowed changes of a state machine.  Illegal changes are reported with a ChangeError holding the old and new values:
+
+    type Order struct {
+        CreatedBy string `gator:"immutable"`
+        Carrier   string `gator:"immutable_once_set"`
+        Status    string `gator:"transition(draft>dispatched, dispatched>delivered)"`
+    }
+
+    err := gator.NewChange(oldOrder, newOrder).Validate()
+    var cErr *gator.ChangeError
+    if errors.As(err, &cErr) {
+        fmt.Println(cErr.Field, cErr.From, cErr.To)
+    }
+
+Unknown tokens are ignored by default.  Strict mode reports unknown tokens, the wrong number of arguments and unparsable arguments as TagErrors instead.  It can be enabled per Gator or for every Gator:
+
+    err := gator.NewStruct(b, gator.WithStrict()).Validate()
+
+    gator.SetStrict(true)
+
+Validate returns the first error encountered.  To collect every failure, use the AllErrors mode:
+
+    err := gator.NewStruct(b, gator.WithMode(gator.AllErrors)).Validate()
+    var errs gator.ValidationErrors
+    if errors.As(err, &errs) {
+        for _, e := range errs {
+            fmt.Println(e.Field, e.Token, e.Arg, e.Value)
+        }
+    }
+
+Errors name fields by their Go names unless another FieldNamer is used.  JSONNames and FormNames use the names in json and form tags and NewFieldNamer creates custom ones.  Each FieldError also holds the field's Path, which can be formatted as a JSON Pointer:
+
+    err := gator.NewStruct(t, gator.WithFieldNamer(gator.JSONNames), gator.WithMode(gator.AllErrors)).Validate()
+    var errs gator.ValidationErrors
+    if errors.As(err, &errs) {
+        for _, e := range errs {
+            fmt.Println(e.Path, e.Path.JSONPointer()) // stops[1].postal_code /stops/1/postal_code
+        }
+    }
+
+Each built-in token has its own message, e.g. "Password must have at least 5 characters.".  Messages are text/templates executed with a MessageData holding the Field, Token, Arg, Args and Value.  A field's messages can be replaced with a gator_msg tag and a token's messages with Registry.SetMessage:
+
+    type Shipment struct {
+        Ref string `gator:"nonzero | alphanum" gator_msg:"{{.Field}} must be a shipper reference."`
+    }
+
+    gator.DefaultRegistry().SetMessage("minlen", "{{.Field}} needs {{.Arg}} or more {{units .Value}}.")
+
+Messages are reported in English unless a locale is given with the WithLocale option or ValidateLocale.  Spanish is built in and other languages can be added as catalogs of JSON or TOML files, with one and other forms for messages that depend on a count.  Messages missing from a catalog fall back to English:
+
+    catalogs, err := gator.LoadCatalogs(os.DirFS("locales")) // e.g. locales/fr.toml
+    if err != nil {
+        return err
+    }
+    for _, c := range catalogs {
+        gator.DefaultRegistry().AddCatalog(c)
+    }
+    err = gator.NewStruct(&shipment).ValidateLocale("es-MX") // "Ref es obligatorio."
+
+Each FieldError also has a Code, e.g. "gator.minlen", and the token's arguments in Params for clients that render their own messages.  FieldErrors encode to JSON with their path as a JSON Pointer, NewProblem describes a result as an RFC 7807 application/problem+json document with an errors member, and Values returns the messages keyed by field:
+
+    if err := gator.NewStruct(&shipment, gator.WithFieldNamer(gator.JSONNames)).Validate(); err != nil {
+        w.Header().Set("Content-Type", gator.ProblemContentType)
+        w.WriteHeader(http.StatusUnprocessableEntity)
+        json.NewEncoder(w).Encode(gator.NewProblem(err))
+    }
+
+NewStruct parses the tags of a type once and caches the result.  To skip creating a Gator for every value, compile a Plan and reuse it:
+
+    var loadPlan = gator.MustCompile(Load{}, gator.WithMode(gator.AllErrors))
+
+    func handle(l *Load) error {
+        return loadPlan.Validate(l)
+    }
+
 Validation logic can be deserialized by gator using a query string:
 
     type WebsiteListing struct {
@@ -58,5 +203,62 @@ Custom tags can be added.  Tokens are added statically and affect all Gators.  H
         fmt.Println(err)
     }
 
+Tokens registered with RegisterStructTagToken are added to the default Registry and affect all Gators.  To scope tokens to some Gators, register them with a Registry cloned from the built-in tokens instead.  The struct tag key can be changed as well:
+
+    r := gator.NewRegistry()
+    r.Register("phone", func(s string) gator.Func {
+        return gator.Matches(`^\d{10}$`)
+    })
+    type Driver struct {
+        Phone string `validate:"phone"`
+    }
+    g := gator.NewStruct(&Driver{}, gator.WithRegistry(r), gator.WithTagKey("validate"))
+
+Tokens that call services can be registered with a ContextFunc instead.  ValidateContext passes its context to them and stops with a *ContextError once the context is canceled or its deadline passes:
+
+    gator.RegisterStructTagTokenContext("known_lane", func(s string) gator.ContextFunc {
+        return func(ctx context.Context, name string, v interface{}) error {
+            return lanes.Check(ctx, v)
+        }
+    })
+    ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+    defer cancel()
+    err := gator.NewStruct(&shipment).ValidateContext(ctx) // errors.Is(err, context.DeadlineExceeded)
+
+Invariants that span several fields can be checked by registering a struct level validation for a type or by implementing GatorValidate() error.  They run after the struct's fields are validated and their errors are reported with the others.  Nested structs with a Validate() error method have it called as well, and failures it repeats from the struct's tags are reported once:
+
+    gator.RegisterStructValidation(Load{}, func(ctx context.Context, v interface{}) error {
+        l := v.(Load)
+        if l.StopsWeight() != l.TotalWeight {
+            return errors.New("the weight of the stops must equal TotalWeight")
+        }
+        return nil
+    })
+
+Tokens that need more than the field's value can be registered with a FieldFunc, which receives a FieldContext holding the field's value, its struct, the root struct, its path, its reflect.StructField and the token's arguments.  AdaptFunc and AdaptContextFunc turn existing Funcs into FieldFuncs:
+
+    gator.RegisterStructTagFieldFunc("share_of", func(fc *gator.FieldContext) error {
+        total, _ := fc.Sibling(fc.Args[0]) // e.g. share_of(Total,50)
+        ...
+    })
+
+exists and unique check a value against a source such as a database table using a Resolver.  Lookups run concurrently once the rest of the struct has been validated, a few at a time, and NewCachingResolver remembers their results.  NewMemoryResolver stands in for a real Resolver in tests:
+
+    type Registration struct {
+        Username string `gator:"nonzero | unique(users.username)"`
+        DOT      int    `gator:"exists(carriers.dot)"`
+    }
+
+    res := gator.NewCachingResolver(db, time.Minute) // db implements gator.Resolver
+    err := gator.NewStruct(&reg, gator.WithResolver(res), gator.WithLookupTimeout(time.Second)).ValidateContext(ctx)
+
+The typed package validates single values with generic rules, so a rule that doesn't fit a value's type fails to compile.  Its Fields are Validators that report the same messages and codes as the equivalent tokens, using the Registry and locale of the Gator they are added to.  Rule.Func turns a rule into a Func and typed.FromFunc does the reverse:
+
+    err := gator.New(gator.WithMode(gator.AllErrors)).Add(
+        typed.NewField("Age", user.Age, typed.Min(18)),
+        typed.NewField("Name", user.Name, typed.Required[string](), typed.MaxLen[string](64)),
+        typed.NewField("Email", user.Email, typed.FromFunc[string](gator.Email())),
+    ).Validate()
+
 */
 package gator
@@ -0,0 +1,171 @@
+package gator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A FieldError describes a field that did not pass validation.
+type FieldError struct {
+	// Field is the name of the field that failed.  It is the String
+	// form of Path.
+	Field string
+	// Path locates the field from the root struct.  It is empty for
+	// errors that don't belong to a field.
+	Path Path
+	// Token is the struct tag token that failed, e.g. "minlen".  It is
+	// empty for Fields created with NewField.
+	Token string
+	// Arg is the argument supplied to Token, e.g. "5" for "minlen(5)".
+	Arg string
+	// Code identifies the failure for machines, e.g. "gator.minlen".  It
+	// is "gator.invalid" for errors that don't belong to a token.
+	Code string
+	// Params holds the arguments of Token for rendering the failure
+	// without parsing Arg: "arg" holds a single argument and "args" a
+	// list of them, with numbers parsed.  Illegal changes add "from" and
+	// "to".  It is nil if there are none.
+	Params map[string]interface{}
+	// Value is the offending value.
+	Value interface{}
+	// Err is the error returned by the Func.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error returned by the Func.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is a collection of FieldErrors returned by a Gator
+// in AllErrors mode.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := []string{}
+	for _, fErr := range e {
+		msgs = append(msgs, fErr.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the FieldErrors so that errors.As and errors.Is can
+// inspect each of them.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fErr := range e {
+		errs[i] = fErr
+	}
+	return errs
+}
+
+// An OrError is returned when a value passes none of the alternatives
+// given to Or or joined by "||" in a gator tag.
+type OrError struct {
+	// Field is the name of the field that failed.
+	Field string
+	// Alternatives holds the tag text of each alternative.  It is nil
+	// for errors returned by Or.
+	Alternatives []string
+	// Errs holds the error returned by each alternative.  Alternatives
+	// in gator tags fail with a *FieldError holding the message and
+	// Code of the token that failed.
+	Errs []error
+}
+
+// Error implements the error interface.
+func (e *OrError) Error() string {
+	msgs := []string{}
+	for i, err := range e.Errs {
+		if i < len(e.Alternatives) {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", e.Alternatives[i], err))
+			continue
+		}
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%s did not pass any alternative - %s", e.Field, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the errors of the alternatives.
+func (e *OrError) Unwrap() []error {
+	return e.Errs
+}
+
+// A ChangeError describes a field whose value changed in a way its
+// gator tag doesn't allow.
+type ChangeError struct {
+	// Field is the name of the field that changed.
+	Field string
+	// From is the old value.
+	From interface{}
+	// To is the new value.
+	To interface{}
+}
+
+// Error implements the error interface.
+func (e *ChangeError) Error() string {
+	return fmt.Sprintf("%s can't change from %v to %v.", e.Field, e.From, e.To)
+}
+
+// A ContextError is returned by ValidateContext when its context is
+// canceled or its deadline passes before validation finishes.  The
+// errors found until then are discarded.
+type ContextError struct {
+	// Err is the context's error, context.Canceled or
+	// context.DeadlineExceeded.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ContextError) Error() string {
+	return "gator: validation stopped - " + e.Err.Error()
+}
+
+// Unwrap returns the context's error.
+func (e *ContextError) Unwrap() error {
+	return e.Err
+}
+
+// A TagError describes a gator tag that could not be parsed.
+type TagError struct {
+	// Field is the struct field (or query string key) the tag belongs to.
+	Field string
+	// Tag is the full tag.
+	Tag string
+	// Col is the 1-based column within Tag where the problem was found.
+	Col int
+	// Msg describes the problem.
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *TagError) Error() string {
+	return fmt.Sprintf("gator: tag for %s has an error at column %d - %s: %q", e.Field, e.Col, e.Msg, e.Tag)
+}
+
+// TagErrors is a collection of TagErrors found while creating a Gator.
+type TagErrors []*TagError
+
+// Error implements the error interface.
+func (e TagErrors) Error() string {
+	msgs := []string{}
+	for _, tErr := range e {
+		msgs = append(msgs, tErr.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the individual TagErrors so errors.As can find them.
+func (e TagErrors) Unwrap() []error {
+	errs := []error{}
+	for _, tErr := range e {
+		errs = append(errs, tErr)
+	}
+	return errs
+}
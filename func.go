@@ -1,11 +1,11 @@
 package gator
 
 import (
-	"errors"
+	"context"
 	"reflect"
+	"regexp"
 	"strconv"
-
-	"github.com/ShaleApps/gator/Godeps/_workspace/src/github.com/onsi/gomega/matchers"
+	"strings"
 )
 
 const (
@@ -15,24 +15,120 @@ const (
 	regexIP       = `^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`
 	regexNum      = `^[1-9]\d*(\.\d+)?$`
 	regexAlpha    = `^[a-zA-Z]*$`
+	regexHostname = `^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`
+)
+
+var (
+	reEmail    = regexp.MustCompile(regexEmail)
+	reHexColor = regexp.MustCompile(regexHexColor)
+	reURL      = regexp.MustCompile(regexURL)
+	reIP       = regexp.MustCompile(regexIP)
+	reNum      = regexp.MustCompile(regexNum)
+	reAlpha    = regexp.MustCompile(regexAlpha)
+	reHostname = regexp.MustCompile(regexHostname)
+	reLetter   = regexp.MustCompile(`[a-zA-Z]+`)
+	reDigit    = regexp.MustCompile(`[0-9]+`)
 )
 
 // Func is a validation function that returns an error if v is invalid.
 type Func func(name string, v interface{}) error
 
-// Matches returns a Func that validates against the given regex.
+// ContextFunc is a Func that receives the context passed to
+// ValidateContext, e.g. for calling services.  It should return promptly
+// once ctx is done.  It receives context.Background() from Validate.
+type ContextFunc func(ctx context.Context, name string, v interface{}) error
+
+// FieldFunc is a validation function that can see the struct holding the
+// field it validates along with the field's metadata.
+type FieldFunc func(fc *FieldContext) error
+
+// A FieldContext describes the field being validated by a FieldFunc.
+type FieldContext struct {
+	// Name is the name of the field.  It is the String form of Path.
+	Name string
+	// Path locates the field from Root.
+	Path Path
+	// Value is the field's value.  Non-nil pointers are dereferenced.
+	Value interface{}
+	// Parent is the struct holding the field and Root is the struct
+	// being validated.  They are invalid for Fields created with
+	// NewField.
+	Parent reflect.Value
+	Root   reflect.Value
+	// Field describes the struct field, e.g. its tags.  It is the zero
+	// StructField for Fields created with NewField.
+	Field reflect.StructField
+	// Token is the token being checked and Args holds its comma
+	// separated arguments.
+	Token string
+	Args  []string
+	// Registry is the Registry the token was found in.
+	Registry *Registry
+
+	ctx context.Context
+}
+
+// Context returns the context passed to ValidateContext or
+// context.Background() for Validate.
+func (fc *FieldContext) Context() context.Context {
+	if fc.ctx == nil {
+		return context.Background()
+	}
+	return fc.ctx
+}
+
+// Sibling returns the value of the field of Parent with the given Go
+// name.  Dotted names such as "Origin.Zip" reach into nested structs
+// and non-nil pointers to them.  ok is false if there is no such
+// exported field.
+func (fc *FieldContext) Sibling(name string) (v interface{}, ok bool) {
+	f := fc.Parent
+	for _, part := range strings.Split(name, ".") {
+		f = pointee(f)
+		if f.Kind() != reflect.Struct {
+			return nil, false
+		}
+		if f = f.FieldByName(part); !f.IsValid() || !f.CanInterface() {
+			return nil, false
+		}
+	}
+	return f.Interface(), true
+}
+
+// AdaptFunc returns a FieldFunc that calls f with the name and value of
+// the field.
+func AdaptFunc(f Func) FieldFunc {
+	return func(fc *FieldContext) error {
+		return f(fc.Name, fc.Value)
+	}
+}
+
+// AdaptContextFunc returns a FieldFunc that calls f with the context,
+// name and value of the field.
+func AdaptContextFunc(f ContextFunc) FieldFunc {
+	return func(fc *FieldContext) error {
+		return f(fc.Context(), fc.Name, fc.Value)
+	}
+}
+
+// Matches returns a Func that validates against the given regex.  The
+// regex is compiled once.  If it can't be compiled the Func always
+// fails.  Strings, byte slices and fmt.Stringers can be matched.
 func Matches(regex string) Func {
-	m := &matchers.MatchRegexpMatcher{Regexp: regex}
-	return match(m)
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return func(name string, v interface{}) error {
+			return formatError(name)
+		}
+	}
+	return matchRegexp(re)
 }
 
 // Nonzero returns a Func that validates its value is non-zero.
 // http://golang.org/pkg/reflect/#Zero
 func Nonzero() Func {
 	return func(name string, v interface{}) error {
-		m := &matchers.BeZeroMatcher{}
-		zero, _ := m.Match(v)
-		if zero {
+		if isZero(v) {
 			return formatError(name)
 		}
 		return nil
@@ -45,20 +141,19 @@ func Nonzero() Func {
 // the value is a built-in number type and v is a string.  Strings are converted into
 // numbers if parsable to support struct tags.
 func Eq(v interface{}) Func {
+	n, isNum := toNumber(v)
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		n, isNum = number{kind: floatKind, f: f}, err == nil
+	}
 	return func(k string, ov interface{}) error {
 		switch ov.(type) {
 		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
-			switch vt := v.(type) {
-			case string:
-				n, err := strconv.ParseFloat(vt, 64)
-				if err != nil {
-					return formatError(k)
-				}
-				f := numericalMatch("==", n)
-				return f(k, ov)
+			on, _ := toNumber(ov)
+			if !isNum || !compareNumbers(eq, on, n) {
+				return formatError(k)
 			}
-			f := numericalMatch("==", v)
-			return f(k, ov)
+			return nil
 		}
 		if !reflect.DeepEqual(v, ov) {
 			return formatError(k)
@@ -69,72 +164,84 @@ func Eq(v interface{}) Func {
 
 // Email returns a Func that validates its value is an email address.
 func Email() Func {
-	return Matches(regexEmail)
+	return matchRegexp(reEmail)
 }
 
 // HexColor returns a Func that validates its value is a hexidecimal number prefixed by a hash.
 // HTML standard link: http://www.w3.org/TR/REC-html40/types.html#h-6.5
 func HexColor() Func {
-	return Matches(regexHexColor)
+	return matchRegexp(reHexColor)
 }
 
 // URL returns a Func that validates its value is a URL.
 func URL() Func {
-	return Matches(regexURL)
+	return matchRegexp(reURL)
 }
 
 // IP returns a Func that validates its value is an IP address.
 func IP() Func {
-	return Matches(regexIP)
+	return matchRegexp(reIP)
+}
+
+// Hostname returns a Func that validates its value is a hostname as
+// defined by RFC 1123.
+func Hostname() Func {
+	return func(name string, v interface{}) error {
+		s, ok := toString(v)
+		if !ok || len(s) > 253 || !reHostname.MatchString(s) {
+			return formatError(name)
+		}
+		return nil
+	}
 }
 
 // Alpha returns a Func that validates its value contains only letters.
 func Alpha() Func {
-	return Matches(regexAlpha)
+	return matchRegexp(reAlpha)
 }
 
 // Num returns a Func that validates its value contains only numbers.
 func Num() Func {
-	return Matches(regexNum)
+	return matchRegexp(reNum)
 }
 
 // AlphaNum returns a Func that validates its value contains both numbers and letters.
 func AlphaNum() Func {
-	return combineFuncs(Matches("[a-zA-Z]+"), Matches("[0-9]+"))
+	return All(matchRegexp(reLetter), matchRegexp(reDigit))
 }
 
 // Gt returns a Func that validates its value is a number greater than v.
 func Gt(v interface{}) Func {
-	return numericalMatch(">", v)
+	return numericalMatch(gt, v)
 }
 
 // Gte returns a Func that validates its value is a number greater than or equal to v.
 func Gte(v interface{}) Func {
-	return numericalMatch(">=", v)
+	return numericalMatch(gte, v)
 }
 
 // Lt returns a Func that validates its value is a number less than v.
 func Lt(v interface{}) Func {
-	return numericalMatch("<", v)
+	return numericalMatch(lt, v)
 }
 
 // Lte returns a Func that validates its value is a number less than or equal to v.
 func Lte(v interface{}) Func {
-	return numericalMatch("<=", v)
+	return numericalMatch(lte, v)
 }
 
 // Lat returns a Func that validates its value is a decimal between 90 and -90.
 func Lat() Func {
-	return combineFuncs(
-		numericalMatch("<=", 90.0),
-		numericalMatch(">=", -90.0))
+	return All(
+		numericalMatch(lte, 90.0),
+		numericalMatch(gte, -90.0))
 }
 
 // Lon returns a Func that validates its value is a decimal between 180 and -180.
 func Lon() Func {
-	return combineFuncs(
-		numericalMatch("<=", 180.0),
-		numericalMatch(">=", -180.0))
+	return All(
+		numericalMatch(lte, 180.0),
+		numericalMatch(gte, -180.0))
 }
 
 // In returns a Func that validates its value is in the inputed list.  Comparisons
@@ -217,29 +324,33 @@ func Each(funcs ...Func) Func {
 	}
 }
 
-type matcher interface {
-	Match(actual interface{}) (success bool, err error)
-}
-
-func numericalMatch(comparator string, v interface{}) Func {
-	m := &matchers.BeNumericallyMatcher{
-		Comparator: comparator,
-		CompareTo:  []interface{}{v},
+// numericalMatch returns a Func that validates its value is a number
+// that compares to v using c.  If v isn't a number the Func always
+// fails.
+func numericalMatch(c comparator, v interface{}) Func {
+	n, ok := toNumber(v)
+	return func(name string, actual interface{}) error {
+		a, isNum := toNumber(actual)
+		if !ok || !isNum || !compareNumbers(c, a, n) {
+			return formatError(name)
+		}
+		return nil
 	}
-	return match(m)
 }
 
-func match(m matcher) Func {
+func matchRegexp(re *regexp.Regexp) Func {
 	return func(name string, v interface{}) error {
-		matches, _ := m.Match(v)
-		if !matches {
+		s, ok := toString(v)
+		if !ok || !re.MatchString(s) {
 			return formatError(name)
 		}
 		return nil
 	}
 }
 
-func combineFuncs(funcs ...Func) Func {
+// All returns a Func that validates its value passes every one of funcs.
+// The first error is returned.
+func All(funcs ...Func) Func {
 	return func(name string, v interface{}) error {
 		for _, f := range funcs {
 			if err := f(name, v); err != nil {
@@ -250,6 +361,54 @@ func combineFuncs(funcs ...Func) Func {
 	}
 }
 
+// Or returns a Func that validates its value passes at least one of
+// funcs.  If none pass an *OrError holding each of their errors is
+// returned.
+func Or(funcs ...Func) Func {
+	checks := []checkFunc{}
+	for _, f := range funcs {
+		checks = append(checks, funcCheck(f))
+	}
+	check := orCheck(nil, checks)
+	return func(name string, v interface{}) error {
+		return check(&fieldContext{name: name, value: v})
+	}
+}
+
+// Not returns a Func that validates its value doesn't pass f.
+func Not(f Func) Func {
+	check := notCheck(funcCheck(f))
+	return func(name string, v interface{}) error {
+		return check(&fieldContext{name: name, value: v})
+	}
+}
+
+// orCheck returns a checkFunc that passes if any of alts pass.  names
+// describe alts in the error returned when none do.
+func orCheck(names []string, alts []checkFunc) checkFunc {
+	return func(fc *fieldContext) error {
+		errs := make([]error, 0, len(alts))
+		for _, alt := range alts {
+			err := alt(fc)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
+		}
+		return &OrError{Field: fc.name, Alternatives: names, Errs: errs}
+	}
+}
+
+// notCheck returns a checkFunc that passes if check fails.
+func notCheck(check checkFunc) checkFunc {
+	return func(fc *fieldContext) error {
+		if check(fc) == nil {
+			return formatError(fc.name)
+		}
+		return nil
+	}
+}
+
 func formatError(name string) error {
-	return errors.New(name + " did not pass validation.")
+	return &failedError{name: name}
 }
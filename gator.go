@@ -1,14 +1,19 @@
 package gator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
-	"strconv"
-	"strings"
+	"reflect"
+	"sync/atomic"
 )
 
 const (
 	structTagKey = "gator"
+	// DefaultGroup is the validation group of rules that aren't given
+	// one.  It is the only group validated unless Groups is used.
+	DefaultGroup = "default"
 )
 
 // Validator is the interface that wraps the basic Validate method.
@@ -16,48 +21,234 @@ type Validator interface {
 	Validate() error
 }
 
+// ContextValidator is implemented by Validators that can be canceled.
+// Gator.ValidateContext calls ValidateContext instead of Validate on
+// Validators that implement it.
+type ContextValidator interface {
+	Validator
+	ValidateContext(ctx context.Context) error
+}
+
+// A MessageValidator is a Validator whose messages are rendered using a
+// Registry in a locale, e.g. those of package typed.  A Gator calls
+// ValidateMessages with its Registry and the locale being validated
+// instead of Validate on Validators that implement it.
+type MessageValidator interface {
+	Validator
+	ValidateMessages(ctx context.Context, reg *Registry, locale string) error
+}
+
+// StructValidator is implemented by structs with invariants that span
+// several fields.  NewStruct calls GatorValidate on the struct and every
+// struct reachable from it after their fields are validated.  Nested
+// structs that implement Validator but not StructValidator have their
+// Validate method called instead.  GatorValidate must not validate the
+// struct using NewStruct itself.
+type StructValidator interface {
+	GatorValidate() error
+}
+
+// StructFunc is a struct level validation registered with
+// RegisterStructValidation.  v is the struct being validated and ctx
+// the context passed to ValidateContext.
+type StructFunc func(ctx context.Context, v interface{}) error
+
+// Mode determines how a Gator reports Validators that fail.
+type Mode int
+
+const (
+	// FirstError stops at the first failing Validator and returns its
+	// error.  It is the default Mode.
+	FirstError Mode = iota
+	// AllErrors runs every Validator and returns a ValidationErrors
+	// containing each failure.
+	AllErrors
+)
+
 // Gator is a Validator that is comprised of other Validators.
 type Gator struct {
-	vals []Validator
+	vals       []Validator
+	mode       Mode
+	strict     bool
+	unexported bool
+	reg        *Registry
+	tagKey     string
+	groups     []string
+	fields     fieldSet
+	namer      *FieldNamer
+	locale     string
+	lookup     lookupConfig
+}
+
+var strictDefault int32
+
+// SetStrict sets whether Gators created afterwards are strict by
+// default.  See WithStrict.
+func SetStrict(strict bool) {
+	var v int32
+	if strict {
+		v = 1
+	}
+	atomic.StoreInt32(&strictDefault, v)
 }
 
 // New creates an initialized Gator.
 func New(options ...func(*Gator)) *Gator {
-	return &Gator{vals: []Validator{}}
+	g := &Gator{
+		vals:   []Validator{},
+		strict: atomic.LoadInt32(&strictDefault) == 1,
+		reg:    defaultRegistry,
+		tagKey: structTagKey,
+		namer:  GoNames,
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// WithMode returns an option that sets the Mode of a Gator.
+func WithMode(m Mode) func(*Gator) {
+	return func(g *Gator) {
+		g.mode = m
+	}
+}
+
+// WithStrict returns an option that makes NewStruct and NewQueryStr
+// reject unknown tokens, tokens with the wrong number of arguments and
+// unparsable arguments.  Every problem is reported as a TagError in a
+// TagErrors returned from Validate.
+func WithStrict() func(*Gator) {
+	return func(g *Gator) {
+		g.strict = true
+	}
+}
+
+// WithRegistry returns an option that makes a Gator look up struct tag
+// tokens in r instead of the default Registry.
+func WithRegistry(r *Registry) func(*Gator) {
+	return func(g *Gator) {
+		g.reg = r
+	}
+}
+
+// WithTagKey returns an option that makes NewStruct read rules from the
+// struct tag with the given key instead of "gator".
+func WithTagKey(key string) func(*Gator) {
+	return func(g *Gator) {
+		g.tagKey = key
+	}
+}
+
+// WithFieldNamer returns an option that makes NewStruct and NewQueryStr
+// name fields in errors using n, e.g. JSONNames.  Tags still refer to
+// fields by their Go names.
+func WithFieldNamer(n *FieldNamer) func(*Gator) {
+	return func(g *Gator) {
+		g.namer = n
+	}
+}
+
+// WithLocale returns an option that makes Validate report messages in
+// locale, e.g. "es", using the Catalogs of the Gator's Registry.  See
+// Gator.ValidateLocale.
+func WithLocale(locale string) func(*Gator) {
+	return func(g *Gator) {
+		g.locale = locale
+	}
+}
+
+// Groups returns an option that makes NewStruct and NewQueryStr
+// validate the rules belonging to the given validation groups instead of
+// DefaultGroup.  Rules are assigned to groups in tags by prefixing them
+// with the group names, e.g. `gator:"create:nonzero; update:omitempty | email"`.
+// Include DefaultGroup to validate rules without a group as well.
+func Groups(names ...string) func(*Gator) {
+	return func(g *Gator) {
+		g.groups = names
+	}
+}
+
+// OnlyFields returns an option that makes NewStruct and NewQueryStr
+// validate only the fields with the given paths, e.g. "Origin.Zip".
+// Selecting a field selects the fields nested in it as well.  Indexes
+// such as "Shipments[0]" are ignored, so the fields of every element are
+// selected.  Rules of fields that aren't selected, such as nonzero, are
+// not checked.
+func OnlyFields(paths ...string) func(*Gator) {
+	return func(g *Gator) {
+		if g.fields == nil {
+			g.fields = fieldSet{}
+		}
+		for _, path := range paths {
+			g.fields.add(path)
+		}
+	}
+}
+
+// WithUnexported returns an option that makes NewStruct and NewQueryStr
+// validate unexported fields instead of skipping them.  Their values
+// are copied using reflection, so only fields holding booleans,
+// numbers, strings and pointers, slices and arrays of them are
+// validated.
+func WithUnexported() func(*Gator) {
+	return func(g *Gator) {
+		g.unexported = true
+	}
 }
 
 // NewStruct generates validation fields based on src's gator struct
-// tags and adds them to the returned gator.  If src isn't a struct
-// or pointer to a struct an error will be returned from the Validation
-// method.
-func NewStruct(src interface{}) *Gator {
-	g := New()
-	objT, objV, err := getReflectInfo(src)
+// tags and adds them to the returned gator.  Nested structs, non-nil
+// pointers to structs, embedded structs and the structs held in
+// slices, arrays and maps are validated as well, with fields named by
+// their path from src, e.g. "Shipments[3].Origin.Zip".  Tags are
+// compiled into a Plan that is cached for src's type and field values
+// are read when Validate is called.  If src isn't a struct or pointer
+// to a struct an error will be returned from the Validation method.
+func NewStruct(src interface{}, options ...func(*Gator)) *Gator {
+	g := New(options...)
+	objT, _, err := getReflectInfo(src)
 	if err != nil {
 		g.Add(errValidator{err: err})
 		return g
 	}
 
-	for i := 0; i < objT.NumField(); i++ {
-		field := objT.Field(i)
-		tag := field.Tag.Get(structTagKey)
-		name := field.Name
-		funcs := funcsFromTag(tag)
-		value := objV.Field(i).Interface()
+	p, errs := g.compile(objT)
+	g.Add(planValidator{p: p, src: src})
+	return g.withTagErrors(errs)
+}
 
-		for _, f := range funcs {
-			g.Add(NewField(name, value, f))
+// NewChange is like NewStruct but validates new as a change from old,
+// which must be of the same type.  Tokens such as immutable,
+// immutable_once_set and transition compare each field to its old
+// value and report illegal changes with a *ChangeError.  They pass when
+// used with NewStruct.
+func NewChange(old, new interface{}, options ...func(*Gator)) *Gator {
+	g := New(options...)
+	objT, _, err := getReflectInfo(new)
+	if err == nil {
+		var oldT reflect.Type
+		oldT, _, err = getReflectInfo(old)
+		if err == nil && oldT != objT {
+			err = fmt.Errorf("gator: can't validate a change from %s to %s", oldT, objT)
 		}
 	}
-	return g
+	if err != nil {
+		g.Add(errValidator{err: err})
+		return g
+	}
+
+	p, errs := g.compile(objT)
+	g.Add(planValidator{p: p, src: new, old: old})
+	return g.withTagErrors(errs)
 }
 
 // NewQueryStr generates validation fields by parsing queryStr using
 // url.ParseQuery and adds them to the returned gator.  If the queryStr
 // can't be parsed or if src isn't a struct or pointer to a struct an
 // error will be returned in the validate function.
-func NewQueryStr(src interface{}, queryStr string) *Gator {
-	g := New()
+func NewQueryStr(src interface{}, queryStr string, options ...func(*Gator)) *Gator {
+	g := New(options...)
 	m, err := url.ParseQuery(queryStr)
 	if err != nil {
 		err = fmt.Errorf("gator: couldn't parse QueryStr - %s", err)
@@ -65,25 +256,39 @@ func NewQueryStr(src interface{}, queryStr string) *Gator {
 		return g
 	}
 
-	objT, objV, err := getReflectInfo(src)
+	objT, _, err := getReflectInfo(src)
 	if err != nil {
 		g.Add(errValidator{err: err})
 		return g
 	}
 
+	cfg := g.compileConfig()
+	sp := &structPlan{typ: objT, fieldsOnly: true}
+	c := &compiler{reg: cfg.reg, strict: cfg.strict, typ: objT}
 	for i := 0; i < objT.NumField(); i++ {
-		name := objT.Field(i).Name
-		value := objV.Field(i).Interface()
-
+		field := objT.Field(i)
+		if field.PkgPath != "" && !cfg.unexported {
+			continue
+		}
+		fp := &fieldPlan{index: i, field: field, name: field.Name, pathName: cfg.namer.fieldName(field)}
 		for key, values := range m {
-			if key == name {
+			if key == field.Name {
 				for _, v := range values {
-					for _, f := range funcsFromTag(v) {
-						g.Add(NewField(name, value, f))
-					}
+					fp.rules = append(fp.rules, c.rules(field.Name, v, field.Tag.Get(cfg.tagKey+"_msg"))...)
 				}
 			}
 		}
+		sp.fields = append(sp.fields, fp)
+	}
+	p := g.plan(objT, sp, cfg)
+	g.Add(planValidator{p: p, src: src})
+	return g.withTagErrors(c.errs)
+}
+
+// withTagErrors makes errs the first error returned from Validate.
+func (g *Gator) withTagErrors(errs TagErrors) *Gator {
+	if len(errs) > 0 {
+		g.vals = append([]Validator{errValidator{err: errs}}, g.vals...)
 	}
 	return g
 }
@@ -96,21 +301,93 @@ func (g *Gator) Add(v Validator, vals ...Validator) *Gator {
 }
 
 // Validate implements the Validator interface and returns an error if
-// any of the Validators added return an error.
+// any of the Validators added return an error.  In FirstError mode the
+// first error is returned as is.  In AllErrors mode every Validator is
+// run and failures are collected into a ValidationErrors.
 func (g *Gator) Validate() error {
+	return g.validate(context.Background(), g.locale)
+}
+
+// ValidateContext is like Validate but passes ctx to ContextValidators
+// and the ContextFuncs of tokens.  Validation stops with a *ContextError
+// once ctx is done.
+func (g *Gator) ValidateContext(ctx context.Context) error {
+	return g.validate(ctx, g.locale)
+}
+
+// ValidateLocale is like Validate but reports the messages of failed
+// tokens in locale, e.g. "es" or "es-MX", using the Catalogs of the
+// Gator's Registry.  Messages missing from the Catalogs are reported in
+// English.
+func (g *Gator) ValidateLocale(locale string) error {
+	return g.validate(context.Background(), locale)
+}
+
+// validator is implemented by Validators that can be canceled and whose
+// messages can be localized.
+type validator interface {
+	validate(ctx context.Context, locale string) error
+}
+
+func validate(ctx context.Context, v Validator, reg *Registry, locale string) error {
+	switch v := v.(type) {
+	case validator:
+		return v.validate(ctx, locale)
+	case MessageValidator:
+		return v.ValidateMessages(ctx, reg, locale)
+	case ContextValidator:
+		return v.ValidateContext(ctx)
+	}
+	return v.Validate()
+}
+
+func (g *Gator) validate(ctx context.Context, locale string) error {
+	errs := ValidationErrors{}
 	for _, v := range g.vals {
-		if err := v.Validate(); err != nil {
+		if err := ctx.Err(); err != nil {
+			return &ContextError{Err: err}
+		}
+		err := validate(ctx, v, g.reg, locale)
+		if err == nil {
+			continue
+		}
+		if g.mode != AllErrors {
 			return err
 		}
+		var tErrs TagErrors
+		var cErr *ContextError
+		if _, ok := v.(errValidator); ok || errors.As(err, &tErrs) || errors.As(err, &cErr) {
+			return err
+		}
+		errs = append(errs, fieldErrors(err)...)
 	}
-	return nil
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// fieldErrors returns the FieldErrors held by err.  Other errors are
+// wrapped in a FieldError that doesn't belong to a field.
+func fieldErrors(err error) ValidationErrors {
+	var vErrs ValidationErrors
+	var fErr *FieldError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &vErrs):
+		return vErrs
+	case errors.As(err, &fErr):
+		return ValidationErrors{fErr}
+	}
+	return ValidationErrors{{Code: errorCode(""), Err: err}}
 }
 
 // A Field is a named value that is validated against a supplied Func.
 type Field struct {
 	name string
 	src  interface{}
-	f    Func
+	rule rule
 }
 
 // NewField creates an initialized Field
@@ -118,106 +395,19 @@ func NewField(name string, src interface{}, f Func) *Field {
 	return &Field{
 		name: name,
 		src:  src,
-		f:    f,
+		rule: rule{check: funcCheck(f)},
 	}
 }
 
 // Validate implements the Validator interface.  Field's Validate
-// method calls the Func supplied during initialization.
+// method calls the Func supplied during initialization and wraps any
+// error it returns, or any panic it raises, in a *FieldError.
 func (f *Field) Validate() error {
-	return f.f(f.name, f.src)
-}
-
-// RegisterStructTagToken registers custom tokens for gator struct tags.
-func RegisterStructTagToken(token string, convFunc func(string) Func) {
-	textToFuncMap[token] = convFunc
-}
-
-var (
-	textToFuncMap = map[string]func(string) Func{}
-)
-
-func init() {
-	RegisterStructTagToken("nonzero", func(s string) Func { return Nonzero() })
-	RegisterStructTagToken("eq", func(s string) Func { return Eq(s) })
-	RegisterStructTagToken("email", func(s string) Func { return Email() })
-	RegisterStructTagToken("hexcolor", func(s string) Func { return HexColor() })
-	RegisterStructTagToken("url", func(s string) Func { return URL() })
-	RegisterStructTagToken("ip", func(s string) Func { return IP() })
-	RegisterStructTagToken("alpha", func(s string) Func { return Alpha() })
-	RegisterStructTagToken("num", func(s string) Func { return Num() })
-	RegisterStructTagToken("alphanum", func(s string) Func { return AlphaNum() })
-	RegisterStructTagToken("matches", func(s string) Func { return Matches(s) })
-	RegisterStructTagToken("lat", func(s string) Func { return Lat() })
-	RegisterStructTagToken("lon", func(s string) Func { return Lon() })
-	RegisterStructTagToken("gt", func(s string) Func {
-		n, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return Gt(n)
-	})
-	RegisterStructTagToken("gte", func(s string) Func {
-		n, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return Gte(n)
-	})
-	RegisterStructTagToken("lt", func(s string) Func {
-		n, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return Lt(n)
-	})
-	RegisterStructTagToken("lte", func(s string) Func {
-		n, err := strconv.ParseFloat(s, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return Lte(n)
-	})
-	RegisterStructTagToken("in", func(s string) Func {
-		list := strings.Split(s, ",")
-		iList := []interface{}{}
-		for _, i := range list {
-			iList = append(iList, i)
-		}
-		return In(iList)
-	})
-	RegisterStructTagToken("notin", func(s string) Func {
-		list := strings.Split(s, ",")
-		iList := []interface{}{}
-		for _, i := range list {
-			iList = append(iList, i)
-		}
-		return NotIn(iList)
-	})
-	RegisterStructTagToken("len", func(s string) Func {
-		n, err := strconv.ParseInt(s, 10, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return Len(int(n))
-	})
-	RegisterStructTagToken("minlen", func(s string) Func {
-		n, err := strconv.ParseInt(s, 10, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return MinLen(int(n))
-	})
-	RegisterStructTagToken("maxlen", func(s string) Func {
-		n, err := strconv.ParseInt(s, 10, 64)
-		if err != nil {
-			return textErrorFunc(s, err)
-		}
-		return MaxLen(int(n))
-	})
-	RegisterStructTagToken("each", func(s string) Func {
-		return Each(funcsFromTag(s)...)
-	})
+	fc := &fieldContext{name: f.name, path: Path{{Field: f.name}}, value: f.src}
+	if fErr, _ := f.rule.run(fc); fErr != nil {
+		return fErr
+	}
+	return nil
 }
 
 func textErrorFunc(s string, err error) Func {
@@ -226,48 +416,29 @@ func textErrorFunc(s string, err error) Func {
 	}
 }
 
-func funcsFromTag(tag string) []Func {
-	funcs := []Func{}
-	sects := strings.Split(tag, "|")
-	tSects := []string{}
-	for _, s := range sects {
-		tSects = append(tSects, strings.TrimSpace(s))
-	}
-	for _, s := range tSects {
-		nCap := nonCaptureString(s)
-		cap := captureString(s)
-		for tag, f := range textToFuncMap {
-			if nCap == tag {
-				fn := f(cap)
-				funcs = append(funcs, fn)
-				break
-			}
-		}
-	}
-	return funcs
+type errValidator struct {
+	err error
 }
 
-func nonCaptureString(s string) string {
-	start := strings.Index(s, "(")
-	if start == -1 {
-		return s
-	}
-	return strings.TrimSpace(s[0:start])
+func (e errValidator) Validate() error {
+	return e.err
 }
 
-func captureString(s string) string {
-	start := strings.Index(s, "(")
-	end := strings.LastIndex(s, ")")
-	if start == -1 || end == -1 || start > end {
-		return ""
-	}
-	return strings.TrimSpace(s[start+1 : end])
+// planValidator validates src using a Plan.  If old is set the change
+// from old to src is validated.
+type planValidator struct {
+	p   *Plan
+	src interface{}
+	old interface{}
 }
 
-type errValidator struct {
-	err error
+func (v planValidator) Validate() error {
+	return v.validate(context.Background(), v.p.locale)
 }
 
-func (e errValidator) Validate() error {
-	return e.err
+func (v planValidator) validate(ctx context.Context, locale string) error {
+	if v.old != nil {
+		return v.p.validateChange(ctx, locale, v.old, v.src)
+	}
+	return v.p.validate(ctx, locale, v.src)
 }
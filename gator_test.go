@@ -1,7 +1,20 @@
 package gator_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/ShaleApps/gator"
 )
@@ -127,6 +140,11 @@ var (
 		gator.NewField("test", 1, gator.Eq(1.0)),
 		gator.NewField("test", "hello", gator.Eq("hello")),
 		gator.NewField("test", "1", gator.Eq("1")),
+		gator.NewField("test", age(21), gator.Gt(18)),
+		gator.NewField("test", uint8(3), gator.Gt(-1)),
+		gator.NewField("test", int64(-3), gator.Lt(uint(1))),
+		gator.NewField("test", []byte("abc"), gator.Alpha()),
+		gator.NewField("test", age(21), gator.Matches("^21$")),
 	}
 
 	invalidFields = []*gator.Field{
@@ -167,9 +185,21 @@ var (
 		gator.NewField("test", []int{1, 2, 3}, gator.Each(gator.Lt(3))),
 		gator.NewField("test", -1, gator.Eq(1.0)),
 		gator.NewField("test", "hello", gator.Eq("hell0")),
+		gator.NewField("test", age(17), gator.Gt(18)),
+		gator.NewField("test", "19", gator.Gt(18)),
+		gator.NewField("test", 1, gator.Gt("0")),
+		gator.NewField("test", math.NaN(), gator.Lte(1)),
+		gator.NewField("test", "abc", gator.Matches("(")),
+		gator.NewField("test", 1, gator.Eq("one")),
 	}
 )
 
+type age int
+
+func (a age) String() string {
+	return strconv.Itoa(int(a))
+}
+
 func TestGator(t *testing.T) {
 	for _, f := range validFields {
 		if err := gator.New().Add(f).Validate(); err != nil {
@@ -243,7 +273,7 @@ func TestRegister(t *testing.T) {
 		return gator.Matches(`^(?=.*\d)(?=.*[a-z])(?=.*[A-Z]).{4,8}$`)
 	})
 	type User struct {
-		Email    string `gator:”email”`
+		Email    string `gator:"email"`
 		Password string `gator:"pword"`
 	}
 	u := &User{
@@ -254,3 +284,1441 @@ func TestRegister(t *testing.T) {
 		t.Errorf("%+v should have been invalid, but failed to produce an error", u)
 	}
 }
+
+func TestAllErrors(t *testing.T) {
+	src := &testStruct3{
+		URL:      "http://google",
+		Username: "log1",
+	}
+	err := gator.NewStruct(src, gator.WithMode(gator.AllErrors)).Validate()
+	var errs gator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %T %v", err, err)
+	}
+	expected := []struct{ field, token, arg string }{
+		{"URL", "url", ""},
+		{"Username", "minlen", "5"},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %s", len(expected), len(errs), errs)
+	}
+	for i, e := range expected {
+		if errs[i].Field != e.field || errs[i].Token != e.token || errs[i].Arg != e.arg {
+			t.Errorf("expected %+v, got %+v", e, errs[i])
+		}
+	}
+	if errs[1].Value != "log1" {
+		t.Errorf("expected offending value log1, got %v", errs[1].Value)
+	}
+	var fErr *gator.FieldError
+	if !errors.As(err, &fErr) || fErr.Field != "URL" {
+		t.Errorf("expected errors.As to find the first FieldError, got %v", fErr)
+	}
+	errTaken := errors.New("taken")
+	err = gator.New(gator.WithMode(gator.AllErrors)).Add(
+		gator.NewField("Username", "log1", gator.MinLen(5)),
+		gator.NewField("Email", "a@b.co", func(name string, v interface{}) error { return errTaken }),
+	).Validate()
+	if !errors.Is(err, errTaken) {
+		t.Errorf("expected errors.Is to find an entry's error, got %v", err)
+	}
+
+	err = gator.NewStruct(src).Validate()
+	if !errors.As(err, &fErr) || fErr.Field != "URL" {
+		t.Errorf("expected first error for URL, got %v", err)
+	}
+}
+
+type strictStruct struct {
+	Email    string `gator:"emial"`
+	Name     string `gator:"minlenn(5)"`
+	Password string `gator:"minlen(abc) | email(5)"`
+	Zip      string `gator:"minlen"`
+	Ages     []int  `gator:"each(gtt(1))"`
+}
+
+func TestStrict(t *testing.T) {
+	src := &strictStruct{Email: "a@example.com", Password: "password"}
+	if err := gator.NewStruct(src).Validate(); err == nil {
+		t.Error("unparsable argument should fail without strict mode")
+	}
+	src.Password = ""
+	if err := gator.NewQueryStr(src, "Email=emial&Ages=each(gtt(1))").Validate(); err != nil {
+		t.Errorf("unknown tokens should be ignored without strict mode: %s", err)
+	}
+
+	expected := []struct {
+		field string
+		col   int
+	}{
+		{"Email", 1},
+		{"Name", 1},
+		{"Password", 1},
+		{"Password", 15},
+		{"Zip", 1},
+		{"Ages", 6},
+	}
+	check := func(err error) {
+		var tErrs gator.TagErrors
+		if !errors.As(err, &tErrs) {
+			t.Fatalf("expected TagErrors, got %T %v", err, err)
+		}
+		if len(tErrs) != len(expected) {
+			t.Fatalf("expected %d errors, got %d: %s", len(expected), len(tErrs), tErrs)
+		}
+		for i, e := range expected {
+			if tErrs[i].Field != e.field || tErrs[i].Col != e.col {
+				t.Errorf("expected %+v, got %s", e, tErrs[i])
+			}
+		}
+	}
+	check(gator.NewStruct(src, gator.WithStrict()).Validate())
+
+	gator.SetStrict(true)
+	defer gator.SetStrict(false)
+	check(gator.NewStruct(src, gator.WithMode(gator.AllErrors)).Validate())
+
+	holder := &struct{ Any interface{} }{Any: strictStruct{}}
+	for i := 0; i < 2; i++ {
+		var tErrs gator.TagErrors
+		if err := gator.NewStruct(holder).Validate(); !errors.As(err, &tErrs) || len(tErrs) != len(expected) {
+			t.Errorf("expected the TagErrors of structs held in interfaces on every call, got %v", err)
+		}
+	}
+}
+
+type address struct {
+	Zip string `gator:"len(5)"`
+}
+
+type contact struct {
+	Email string `gator:"email"`
+}
+
+type shipment struct {
+	Origin      address
+	Destination *address
+}
+
+type load struct {
+	contact
+	Name      string `gator:"nonzero"`
+	Shipments []shipment
+	Stops     map[string]*address
+	Next      *load
+}
+
+type kin struct {
+	Name string `gator:"nonzero"`
+	Kids []kin
+}
+
+func TestNestedStructs(t *testing.T) {
+	valid := &load{
+		contact:   contact{Email: "gator@example.com"},
+		Name:      "load",
+		Shipments: []shipment{{Origin: address{"12345"}, Destination: &address{"54321"}}},
+		Stops:     map[string]*address{"a": {"12345"}, "b": nil},
+	}
+	valid.Next = valid
+	if err := gator.NewStruct(valid).Validate(); err != nil {
+		t.Errorf("struct should be valid: %s", err)
+	}
+
+	invalid := &load{
+		contact: contact{Email: "gator"},
+		Shipments: []shipment{
+			{Origin: address{"12345"}},
+			{Origin: address{"1234"}, Destination: &address{"123"}},
+		},
+		Stops: map[string]*address{"a": {"1"}},
+	}
+	invalid.Next = &load{Name: "next", Next: invalid}
+	err := gator.NewStruct(invalid, gator.WithMode(gator.AllErrors)).Validate()
+	var errs gator.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	expected := []string{
+		"Email",
+		"Name",
+		"Shipments[1].Origin.Zip",
+		"Shipments[1].Destination.Zip",
+		"Stops[a].Zip",
+		"Next.Email",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d: %s", len(expected), len(errs), errs)
+	}
+	for i, field := range expected {
+		if errs[i].Field != field {
+			t.Errorf("expected error for %s, got %s", field, errs[i].Field)
+		}
+	}
+
+	cyclic := &kin{Kids: []kin{{}}}
+	cyclic.Kids[0].Kids = cyclic.Kids
+	err = gator.NewStruct(cyclic, gator.WithMode(gator.AllErrors)).Validate()
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Field != "Name" || errs[1].Field != "Kids[0].Name" {
+		t.Errorf("expected slices that refer to themselves to be walked once, got %v", err)
+	}
+
+	shared := &address{"1"}
+	src := &shipment{Origin: address{"12345"}, Destination: shared}
+	holder := &struct{ A, B *shipment }{src, src}
+	err = gator.NewStruct(holder, gator.WithMode(gator.AllErrors)).Validate()
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Field != "A.Destination.Zip" || errs[1].Field != "B.Destination.Zip" {
+		t.Errorf("expected shared pointers to be validated at each path, got %v", err)
+	}
+}
+
+type unexportedStruct struct {
+	Name     string `gator:"nonzero"`
+	password string `gator:"minlen(5)"`
+	codes    []int  `gator:"each(lt(10))"`
+	inner    address
+}
+
+func TestNilAndUnexported(t *testing.T) {
+	var nilPtr *testStruct1
+	for _, src := range []interface{}{nil, nilPtr} {
+		if err := gator.NewStruct(src).Validate(); err == nil {
+			t.Errorf("%#v should produce an error", src)
+		}
+		if err := gator.NewQueryStr(src, "Required=nonzero").Validate(); err == nil {
+			t.Errorf("%#v should produce an error", src)
+		}
+	}
+
+	src := &unexportedStruct{Name: "gator", password: "abc", codes: []int{1, 20}, inner: address{"1"}}
+	if err := gator.NewStruct(src).Validate(); err != nil {
+		t.Errorf("unexported fields should be skipped: %s", err)
+	}
+	if err := gator.NewQueryStr(src, "password=minlen(5)").Validate(); err != nil {
+		t.Errorf("unexported fields should be skipped: %s", err)
+	}
+
+	err := gator.NewStruct(src, gator.WithUnexported(), gator.WithMode(gator.AllErrors)).Validate()
+	var errs gator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("expected 3 errors for unexported fields, got %v", err)
+	}
+	for i, field := range []string{"password", "codes", "inner.Zip"} {
+		if errs[i].Field != field {
+			t.Errorf("expected error for %s, got %s", field, errs[i].Field)
+		}
+	}
+}
+
+func TestPanicRecovery(t *testing.T) {
+	gator.RegisterStructTagToken("boom", func(s string) gator.Func {
+		return func(name string, v interface{}) error {
+			panic("boom")
+		}
+	})
+	src := &struct {
+		Name string `gator:"boom"`
+	}{}
+	err := gator.NewStruct(src).Validate()
+	var fErr *gator.FieldError
+	if !errors.As(err, &fErr) || fErr.Field != "Name" || fErr.Token != "boom" {
+		t.Fatalf("expected FieldError for Name, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "boom") || !strings.Contains(err.Error(), "Name") {
+		t.Errorf("error should name the token and field: %s", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	type contactInfo struct {
+		Phone string `gator:"phone" validate:"len(3)"`
+	}
+	r := gator.NewRegistry()
+	r.Register("phone", func(s string) gator.Func {
+		return gator.Matches(`^\d{10}$`)
+	})
+	clone := r.Clone()
+	clone.Register("phone", func(s string) gator.Func {
+		return gator.Matches(`^\d{3}$`)
+	})
+
+	src := &contactInfo{Phone: "123"}
+	if err := gator.NewStruct(src, gator.WithRegistry(r)).Validate(); err == nil {
+		t.Error("phone should be invalid with r")
+	}
+	if err := gator.NewStruct(src, gator.WithRegistry(clone)).Validate(); err != nil {
+		t.Errorf("phone should be valid with clone: %s", err)
+	}
+	if err := gator.NewStruct(src, gator.WithStrict()).Validate(); err == nil {
+		t.Error("phone should be unknown to the default registry")
+	}
+	if err := gator.NewStruct(src, gator.WithTagKey("validate")).Validate(); err != nil {
+		t.Errorf("validate tag should be used: %s", err)
+	}
+	src.Phone = "1234"
+	if err := gator.NewStruct(src, gator.WithTagKey("validate")).Validate(); err == nil {
+		t.Error("validate tag should be used")
+	}
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+	r := gator.NewRegistry()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				r.Register("short", func(s string) gator.Func {
+					return gator.MaxLen(10)
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				src := &struct {
+					Name string `gator:"short"`
+				}{"gator"}
+				if err := gator.NewStruct(src, gator.WithRegistry(r)).Validate(); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type window struct {
+	Start time.Time
+	End   *time.Time `gator:"gtfield(Start)"`
+}
+
+type signup struct {
+	Password        string
+	ConfirmPassword string `gator:"eqfield(Password)"`
+	Username        string `gator:"nefield(Password)"`
+	MinLoad         int
+	MaxLoad         float64 `gator:"gtefield(MinLoad)"`
+	Window          *window
+	Deadline        time.Time `gator:"gtfield(Window.Start) | ltefield(Window.End)"`
+	Retries         []int     `gator:"each(ltfield(MinLoad))"`
+}
+
+func TestFieldComparisons(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(48 * time.Hour)
+	valid := func() *signup {
+		return &signup{
+			Password:        "secret",
+			ConfirmPassword: "secret",
+			Username:        "gator",
+			MinLoad:         10,
+			MaxLoad:         10,
+			Window:          &window{Start: start, End: &end},
+			Deadline:        start.Add(24 * time.Hour),
+			Retries:         []int{1, 9},
+		}
+	}
+	if err := gator.NewStruct(valid()).Validate(); err != nil {
+		t.Fatalf("valid struct should pass: %s", err)
+	}
+
+	early := start.Add(-time.Hour)
+	invalid := map[string]func(s *signup){
+		"ConfirmPassword": func(s *signup) { s.ConfirmPassword = "secret!" },
+		"Username":        func(s *signup) { s.Username = s.Password },
+		"MaxLoad":         func(s *signup) { s.MaxLoad = 9.5 },
+		"Window.End":      func(s *signup) { s.Window.End = &early },
+		"Deadline":        func(s *signup) { s.Window = nil },
+		"Retries":         func(s *signup) { s.Retries = []int{10} },
+	}
+	for field, change := range invalid {
+		src := valid()
+		change(src)
+		var fErr *gator.FieldError
+		err := gator.NewStruct(src).Validate()
+		if !errors.As(err, &fErr) || fErr.Field != field {
+			t.Errorf("expected an error for %s, got %v", field, err)
+		}
+	}
+
+	type missing struct {
+		Confirm string `gator:"eqfield(Pasword)"`
+	}
+	err := gator.NewStruct(&missing{}, gator.WithStrict()).Validate()
+	var tErrs gator.TagErrors
+	if !errors.As(err, &tErrs) || !strings.Contains(err.Error(), `field "Pasword" doesn't exist`) {
+		t.Errorf("expected a TagError for a missing field, got %v", err)
+	}
+	if err := gator.NewStruct(&missing{}).Validate(); err == nil || !strings.Contains(err.Error(), `field "Pasword" doesn't exist`) {
+		t.Errorf("expected an error for a missing field, got %v", err)
+	}
+}
+
+type equipment string
+
+type tender struct {
+	EquipmentType equipment
+	TrailerNumber string `gator:"required_if(EquipmentType,flatbed)"`
+	Tarps         int    `gator:"excluded_if(EquipmentType, 'reefer')"`
+	Temperature   *int   `gator:"required_unless(EquipmentType,flatbed, Tarps,0)"`
+	Phone         string `gator:"required_without(Email)"`
+	Email         string `gator:"required_without(Phone)"`
+	Extension     string
+	Office        string `gator:"required_with(Extension,Window.Start)"`
+	Window        *window
+}
+
+func TestConditionalRequirements(t *testing.T) {
+	temp := -10
+	now := time.Now()
+	later := now.Add(time.Hour)
+	tests := []struct {
+		src   tender
+		field string
+	}{
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1", Email: "a@b.co"}, ""},
+		{tender{EquipmentType: "flatbed", Email: "a@b.co"}, "TrailerNumber"},
+		{tender{EquipmentType: "reefer", Temperature: &temp, Phone: "5555555555"}, ""},
+		{tender{EquipmentType: "reefer", Tarps: 2, Temperature: &temp, Phone: "5555555555"}, "Tarps"},
+		{tender{EquipmentType: "reefer", Phone: "5555555555"}, "Temperature"},
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1", Tarps: 2, Phone: "5555555555"}, "Temperature"},
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1"}, "Phone"},
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1", Phone: "5", Extension: "12"}, "Office"},
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1", Phone: "5", Window: &window{Start: now, End: &later}}, "Office"},
+		{tender{EquipmentType: "flatbed", TrailerNumber: "T1", Phone: "5", Window: &window{End: &now}}, ""},
+	}
+	for i, test := range tests {
+		err := gator.NewStruct(&test.src).Validate()
+		var fErr *gator.FieldError
+		switch {
+		case test.field == "" && err != nil:
+			t.Errorf("%d: expected no error, got %s", i, err)
+		case test.field != "" && (!errors.As(err, &fErr) || fErr.Field != test.field):
+			t.Errorf("%d: expected an error for %s, got %v", i, test.field, err)
+		}
+	}
+
+	type oddArgs struct {
+		Field string `gator:"required_if(Other)"`
+		Other string
+	}
+	if err := gator.NewStruct(&oddArgs{}, gator.WithStrict()).Validate(); err == nil {
+		t.Error("required_if without a value should produce a TagError")
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	host := gator.Or(gator.IP(), gator.Hostname())
+	for _, v := range []string{"10.0.0.1", "example.com"} {
+		if err := host("Host", v); err != nil {
+			t.Errorf("%q should be a host: %s", v, err)
+		}
+	}
+	err := host("Host", "-bad-")
+	var orErr *gator.OrError
+	if !errors.As(err, &orErr) || len(orErr.Errs) != 2 {
+		t.Errorf("expected an OrError with 2 errors, got %v", err)
+	}
+	if err := gator.Not(gator.Num())("Code", "123"); err == nil {
+		t.Error("Not(Num()) should fail for a number")
+	}
+	if err := gator.All(gator.MinLen(2), gator.Not(gator.Eq("ab")))("Code", "ab"); err == nil {
+		t.Error("All should fail if any Func fails")
+	}
+
+	type server struct {
+		Host string `gator:"ip || hostname"`
+	}
+	err = gator.NewStruct(&server{Host: "-bad-"}).Validate()
+	var fErr *gator.FieldError
+	if !errors.As(err, &fErr) || fErr.Token != "or" || fErr.Arg != "ip || hostname" {
+		t.Fatalf("expected a FieldError for the or token, got %v", err)
+	}
+	if !errors.As(err, &orErr) || strings.Join(orErr.Alternatives, ",") != "ip,hostname" {
+		t.Errorf("expected the alternatives tried, got %v", err)
+	}
+
+	type code struct {
+		Code string `gator:"len(3) || (alpha | minlen(5))"`
+	}
+	expected := "Code did not pass any alternative - len(3): Code must have exactly 3 characters.; (alpha | minlen(5)): Code must contain only letters."
+	err = gator.NewStruct(&code{Code: "a1"}).Validate()
+	if !errors.As(err, &orErr) || err.Error() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	var alt *gator.FieldError
+	if !errors.As(orErr.Errs[0], &alt) || alt.Code != "gator.len" || alt.Token != "len" {
+		t.Errorf("expected the alternatives to fail with their own FieldErrors, got %#v", orErr.Errs[0])
+	}
+}
+
+type profile struct {
+	Email    *string   `gator:"omitempty | email"`
+	Website  string    `gator:"omitempty | url"`
+	Age      *int      `gator:"omitnil | gte(18)"`
+	Nickname *string   `gator:"nonzero | minlen(2)"`
+	Aliases  []string  `gator:"omitempty | each(alpha)"`
+	Backups  []*string `gator:"each(omitnil | email)"`
+}
+
+func TestOptionalFields(t *testing.T) {
+	email, nick, zero, bad := "gator@example.com", "al", 0, "nope"
+	if err := gator.NewStruct(&profile{Nickname: &nick}).Validate(); err != nil {
+		t.Errorf("empty optional fields should pass: %s", err)
+	}
+	src := &profile{Email: &email, Website: "example.com", Nickname: &nick, Backups: []*string{nil, &email}}
+	if err := gator.NewStruct(src).Validate(); err != nil {
+		t.Errorf("pointer fields should be validated by their pointee: %s", err)
+	}
+
+	invalid := map[string]*profile{
+		"Email":    {Email: &bad, Nickname: &nick},
+		"Age":      {Age: &zero, Nickname: &nick},
+		"Nickname": {},
+		"Aliases":  {Nickname: &nick, Aliases: []string{"a1"}},
+		"Backups":  {Nickname: &nick, Backups: []*string{&bad}},
+	}
+	for field, src := range invalid {
+		var fErr *gator.FieldError
+		err := gator.NewStruct(src).Validate()
+		if !errors.As(err, &fErr) || fErr.Field != field {
+			t.Errorf("expected an error for %s, got %v", field, err)
+		}
+	}
+
+	empty := ""
+	if err := gator.NewStruct(&profile{Nickname: &empty}).Validate(); err == nil {
+		t.Error("nonzero should fail for a pointer to a zero value")
+	}
+
+	type counter struct {
+		Count *int `gator:"notnil | gte(0)"`
+	}
+	if err := gator.NewStruct(&counter{Count: &zero}).Validate(); err != nil {
+		t.Errorf("notnil should pass for a pointer to a zero value: %s", err)
+	}
+	if err := gator.NewStruct(&counter{}).Validate(); err == nil || err.Error() != "Count is required." {
+		t.Errorf("notnil should fail for a nil pointer, got %v", err)
+	}
+}
+
+type account struct {
+	ID    int    `gator:"create: eq(0); update: gt(0)"`
+	Email string `gator:"create: nonzero | email; update: omitempty | email"`
+	Name  string `gator:"maxlen(5); create, update: alpha"`
+}
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		src    account
+		groups []string
+		field  string
+	}{
+		{account{Name: "toolong"}, nil, "Name"},
+		{account{Name: "a1"}, nil, ""},
+		{account{Email: "a@b.co", Name: "a"}, []string{"create"}, ""},
+		{account{Name: "a"}, []string{"create"}, "Email"},
+		{account{ID: 1, Email: "a@b.co", Name: "a"}, []string{"create"}, "ID"},
+		{account{ID: 1, Name: "toolong"}, []string{"update"}, ""},
+		{account{ID: 1, Name: "toolong"}, []string{"update", gator.DefaultGroup}, "Name"},
+		{account{Name: "a"}, []string{"update"}, "ID"},
+		{account{ID: 1, Name: "a1"}, []string{"update"}, "Name"},
+	}
+	for i, test := range tests {
+		err := gator.NewStruct(&test.src, gator.Groups(test.groups...)).Validate()
+		var fErr *gator.FieldError
+		switch {
+		case test.field == "" && err != nil:
+			t.Errorf("%d: expected no error, got %s", i, err)
+		case test.field != "" && (!errors.As(err, &fErr) || fErr.Field != test.field):
+			t.Errorf("%d: expected an error for %s, got %v", i, test.field, err)
+		}
+	}
+
+	p := gator.MustCompile(account{}, gator.Groups("update"))
+	if err := p.Validate(account{}); err == nil {
+		t.Error("Plans should validate the groups they were compiled with")
+	}
+}
+
+type carrier struct {
+	contact
+	Name    string   `json:"name" gator:"nonzero"`
+	SCAC    string   `json:"scac" gator:"len(4)"`
+	Office  *address `json:"office" gator:"nonzero"`
+	Trucks  []address
+	Skipped string `json:"-" gator:"nonzero"`
+}
+
+func TestPartialValidation(t *testing.T) {
+	src := &carrier{
+		contact: contact{Email: "bad"},
+		SCAC:    "ABCD",
+		Office:  &address{Zip: "1"},
+		Trucks:  []address{{Zip: "12345"}, {Zip: "1"}},
+	}
+	tests := []struct {
+		patch  string
+		fields []string
+	}{
+		{`{"scac": "ABCD"}`, nil},
+		{`{"SCAC": "ABCD", "unknown": 1, "Skipped": ""}`, nil},
+		{`{"name": null}`, []string{"Name"}},
+		{`{"office": {"zip": "1"}}`, []string{"Office.Zip"}},
+		{`{"office": null}`, []string{"Office.Zip"}},
+		{`{"trucks": [{"zip": "12345"}, {"zip": "1"}]}`, []string{"Trucks[1].Zip"}},
+		{`{"Email": "bad", "scac": "ABCD"}`, []string{"Email"}},
+	}
+	for _, test := range tests {
+		err := gator.NewMergePatch(src, []byte(test.patch), gator.WithMode(gator.AllErrors)).Validate()
+		var errs gator.ValidationErrors
+		if len(test.fields) == 0 {
+			if err != nil {
+				t.Errorf("%s should pass: %s", test.patch, err)
+			}
+			continue
+		}
+		if !errors.As(err, &errs) || len(errs) != len(test.fields) {
+			t.Errorf("%s expected errors for %v, got %v", test.patch, test.fields, err)
+			continue
+		}
+		for i, field := range test.fields {
+			if errs[i].Field != field {
+				t.Errorf("%s expected an error for %s, got %s", test.patch, field, errs[i].Field)
+			}
+		}
+	}
+
+	if err := gator.NewMergePatch(src, []byte(`[1]`)).Validate(); err == nil {
+		t.Error("a patch that isn't an object should produce an error")
+	}
+	err := gator.NewStruct(src, gator.OnlyFields("SCAC", "Trucks[0].Zip")).Validate()
+	var fErr *gator.FieldError
+	if !errors.As(err, &fErr) || fErr.Field != "Trucks[1].Zip" {
+		t.Errorf("expected an error for Trucks[1].Zip, got %v", err)
+	}
+	partial := &carrier{SCAC: "ABCD"}
+	if err := gator.NewStruct(partial, gator.OnlyFields("SCAC")).Validate(); err != nil {
+		t.Errorf("fields that aren't selected should be skipped: %s", err)
+	}
+	if err := gator.NewStruct(partial, gator.OnlyFields("Office.Zip")).Validate(); err == nil {
+		t.Error("the rules of a selected field's parents should be checked")
+	}
+}
+
+type status string
+
+type order struct {
+	CreatedBy string `gator:"immutable"`
+	Carrier   string `gator:"immutable_once_set"`
+	Status    status `gator:"transition(draft>dispatched, dispatched>delivered)"`
+	Lines     []line
+	Notes     *address `gator:"immutable"`
+}
+
+type line struct {
+	SKU string `gator:"immutable"`
+}
+
+func TestChanges(t *testing.T) {
+	old := order{CreatedBy: "alice", Status: "draft", Lines: []line{{"A"}}}
+	tests := []struct {
+		change func(o *order)
+		field  string
+		from   interface{}
+		to     interface{}
+	}{
+		{func(o *order) {}, "", nil, nil},
+		{func(o *order) { o.Carrier = "ACME"; o.Status = "dispatched" }, "", nil, nil},
+		{func(o *order) { o.Lines = append(o.Lines, line{"B"}) }, "", nil, nil},
+		{func(o *order) { o.CreatedBy = "bob" }, "CreatedBy", "alice", "bob"},
+		{func(o *order) { o.Status = "delivered" }, "Status", status("draft"), status("delivered")},
+		{func(o *order) { o.Lines = []line{{"B"}} }, "Lines[0].SKU", "A", "B"},
+		{func(o *order) { o.Notes = &address{} }, "Notes", (*address)(nil), address{}},
+	}
+	for i, test := range tests {
+		new := old
+		new.Lines = append([]line{}, old.Lines...)
+		test.change(&new)
+		err := gator.NewChange(&old, &new).Validate()
+		var cErr *gator.ChangeError
+		switch {
+		case test.field == "" && err != nil:
+			t.Errorf("%d: expected no error, got %s", i, err)
+		case test.field == "":
+		case !errors.As(err, &cErr):
+			t.Errorf("%d: expected a ChangeError for %s, got %v", i, test.field, err)
+		case cErr.Field != test.field || cErr.From != test.from || cErr.To != test.to:
+			t.Errorf("%d: expected %s to change from %v to %v, got %s", i, test.field, test.from, test.to, cErr)
+		}
+	}
+
+	set := old
+	set.Carrier = "ACME"
+	changed := set
+	changed.Carrier = "Other"
+	if err := gator.NewChange(set, changed).Validate(); err == nil {
+		t.Error("immutable_once_set should fail once the field is set")
+	}
+	if err := gator.NewStruct(&changed).Validate(); err != nil {
+		t.Errorf("change tokens should pass without an old value: %s", err)
+	}
+	if err := gator.NewChange(&line{}, &old).Validate(); err == nil {
+		t.Error("changes between types should produce an error")
+	}
+	if err := gator.MustCompile(order{}).ValidateChange(old, set); err != nil {
+		t.Errorf("Plans should validate changes: %s", err)
+	}
+}
+
+type stop struct {
+	PostalCode string `json:"postal_code" form:"zip" gator:"len(5)"`
+}
+
+type tenderRequest struct {
+	ShipperRef string           `json:"shipper_ref,omitempty" form:"ref" gator:"nonzero"`
+	Stops      []stop           `json:"stops"`
+	Docks      map[string]*stop `json:"docks"`
+	Internal   string           `json:"-" gator:"nonzero"`
+}
+
+func TestFieldNames(t *testing.T) {
+	src := &tenderRequest{
+		Stops: []stop{{"12345"}, {"1"}},
+		Docks: map[string]*stop{"a/b": {"1"}},
+	}
+	tests := []struct {
+		namer   *gator.FieldNamer
+		fields  []string
+		pointer []string
+	}{
+		{gator.GoNames, []string{"ShipperRef", "Stops[1].PostalCode", "Docks[a/b].PostalCode", "Internal"},
+			[]string{"/ShipperRef", "/Stops/1/PostalCode", "/Docks/a~1b/PostalCode", "/Internal"}},
+		{gator.JSONNames, []string{"shipper_ref", "stops[1].postal_code", "docks[a/b].postal_code", "Internal"},
+			[]string{"/shipper_ref", "/stops/1/postal_code", "/docks/a~1b/postal_code", "/Internal"}},
+		{gator.FormNames, []string{"ref", "Stops[1].zip", "Docks[a/b].zip", "Internal"},
+			[]string{"/ref", "/Stops/1/zip", "/Docks/a~1b/zip", "/Internal"}},
+		{gator.NewFieldNamer(func(f reflect.StructField) string { return strings.ToUpper(f.Name) }),
+			[]string{"SHIPPERREF", "STOPS[1].POSTALCODE", "DOCKS[a/b].POSTALCODE", "INTERNAL"},
+			[]string{"/SHIPPERREF", "/STOPS/1/POSTALCODE", "/DOCKS/a~1b/POSTALCODE", "/INTERNAL"}},
+	}
+	for _, test := range tests {
+		err := gator.NewStruct(src, gator.WithFieldNamer(test.namer), gator.WithMode(gator.AllErrors)).Validate()
+		var errs gator.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != len(test.fields) {
+			t.Errorf("expected errors for %v, got %v", test.fields, err)
+			continue
+		}
+		for i, fErr := range errs {
+			if fErr.Field != test.fields[i] || fErr.Path.String() != test.fields[i] || fErr.Path.JSONPointer() != test.pointer[i] {
+				t.Errorf("expected %s at %s, got %s at %s", test.fields[i], test.pointer[i], fErr.Field, fErr.Path.JSONPointer())
+			}
+		}
+	}
+
+	err := gator.NewQueryStr(src, "ShipperRef=nonzero", gator.WithFieldNamer(gator.JSONNames)).Validate()
+	if err == nil || err.Error() != "shipper_ref is required." {
+		t.Errorf("expected an error for shipper_ref, got %v", err)
+	}
+
+	for namer, expected := range map[*gator.FieldNamer][]string{
+		gator.GoNames:   {"/ID", "/By"},
+		gator.JSONNames: {"/base/id", "/by"},
+	} {
+		err = gator.NewStruct(&taggedTender{}, gator.WithFieldNamer(namer), gator.WithMode(gator.AllErrors)).Validate()
+		var errs gator.ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Path.JSONPointer() != expected[0] || errs[1].Path.JSONPointer() != expected[1] {
+			t.Errorf("expected errors at %v, got %v", expected, err)
+		}
+	}
+}
+
+type tenderBase struct {
+	ID string `json:"id" gator:"nonzero"`
+}
+
+type tenderAudit struct {
+	By string `json:"by" gator:"nonzero"`
+}
+
+type taggedTender struct {
+	tenderBase `json:"base"`
+	tenderAudit
+}
+
+type message struct {
+	Password string   `gator:"minlen(8)"`
+	Tags     []string `gator:"minlen(2)"`
+	Size     string   `gator:"in(S, M, L)"`
+	Ref      string   `gator:"nonzero | alphanum" gator_msg:"{{.Field}} must be a shipper reference ({{.Token}} failed)."`
+	Code     string   `gator:"code"`
+}
+
+func TestMessages(t *testing.T) {
+	src := &message{Password: "abc", Tags: []string{"a"}, Size: "XL", Ref: "---", Code: "x"}
+	r := gator.NewRegistry()
+	r.Register("code", func(string) gator.Func {
+		return gator.Matches(`^\d+$`)
+	})
+	err := gator.NewStruct(src, gator.WithRegistry(r), gator.WithMode(gator.AllErrors)).Validate()
+	expected := []string{
+		"Password must have at least 8 characters.",
+		"Tags must have at least 2 items.",
+		"Size must be one of S, M, L.",
+		"Ref must be a shipper reference (alphanum failed).",
+		"Code did not pass validation.",
+	}
+	if err == nil || err.Error() != strings.Join(expected, "\n") {
+		t.Errorf("expected messages:\n%s\ngot:\n%v", strings.Join(expected, "\n"), err)
+	}
+
+	if err := r.SetMessage("code", "{{.Field}} must be numeric"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetMessage("minlen", "{{.Field}} is too short"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SetMessage("minlen", "{{.Field"); err == nil {
+		t.Error("SetMessage should return an error for an invalid template")
+	}
+	var errs gator.ValidationErrors
+	err = gator.NewStruct(src, gator.WithRegistry(r), gator.WithMode(gator.AllErrors)).Validate()
+	if !errors.As(err, &errs) || errs[0].Error() != "Password is too short" || errs[4].Error() != "Code must be numeric" {
+		t.Errorf("expected Registry messages, got %v", err)
+	}
+	if err := gator.NewStruct(src).Validate(); err == nil || err.Error() != expected[0] {
+		t.Errorf("Registry messages shouldn't affect other Registries, got %v", err)
+	}
+
+	type badMessage struct {
+		Name string `gator:"nonzero" gator_msg:"{{.Field"`
+	}
+	var tErrs gator.TagErrors
+	if err := gator.NewStruct(&badMessage{}).Validate(); !errors.As(err, &tErrs) {
+		t.Errorf("an invalid gator_msg should produce a TagError, got %v", err)
+	}
+}
+
+type driver struct {
+	Name  string   `gator:"nonzero"`
+	Code  string   `gator:"minlen(1)"`
+	Tags  []string `gator:"minlen(2)"`
+	Radio string   `gator:"callsign"`
+}
+
+func TestLocales(t *testing.T) {
+	src := &driver{Tags: []string{"a"}, Radio: "x"}
+	r := gator.NewRegistry()
+	r.Register("callsign", func(string) gator.Func {
+		return gator.Matches(`^[A-Z]{4}$`)
+	})
+	if err := r.SetMessage("callsign", "{{.Field}} must be a call sign."); err != nil {
+		t.Fatal(err)
+	}
+	english := []string{
+		"Name is required.",
+		"Code must have at least 1 character.",
+		"Tags must have at least 2 items.",
+		"Radio must be a call sign.",
+	}
+	spanish := []string{
+		"Name es obligatorio.",
+		"Code debe tener al menos 1 carácter.",
+		"Tags debe tener al menos 2 elementos.",
+		"Radio must be a call sign.",
+	}
+	tests := []struct {
+		locale   string
+		messages []string
+	}{
+		{"", english},
+		{"en-US", english},
+		{"fr", english},
+		{"es", spanish},
+		{"es_MX", spanish},
+	}
+	for _, test := range tests {
+		g := gator.NewStruct(src, gator.WithRegistry(r), gator.WithMode(gator.AllErrors), gator.WithLocale(test.locale))
+		expected := strings.Join(test.messages, "\n")
+		if err := g.Validate(); err == nil || err.Error() != expected {
+			t.Errorf("%q expected:\n%s\ngot:\n%v", test.locale, expected, err)
+		}
+		g = gator.NewStruct(src, gator.WithRegistry(r), gator.WithMode(gator.AllErrors))
+		if err := g.ValidateLocale(test.locale); err == nil || err.Error() != expected {
+			t.Errorf("%q expected:\n%s\ngot:\n%v", test.locale, expected, err)
+		}
+	}
+
+	catalogs, err := gator.LoadCatalogs(fstest.MapFS{
+		"es-mx.toml": {Data: []byte(`# Mexican Spanish
+locale = "es-MX"
+
+[messages]
+callsign = "{{.Field}} debe ser un indicativo."
+
+[messages.minlen]
+one = '{{.Field}} necesita {{.Arg}} carácter.'
+other = "{{.Field}} necesita {{.Arg}} caracteres."
+`)},
+		"de.json":   {Data: []byte(`{"locale": "de", "messages": {"nonzero": "{{.Field}} ist erforderlich."}}`)},
+		"README.md": {Data: []byte(`not a catalog`)},
+	})
+	if err != nil || len(catalogs) != 2 {
+		t.Fatalf("expected 2 catalogs, got %v, %v", catalogs, err)
+	}
+	for _, c := range catalogs {
+		r.AddCatalog(c)
+	}
+	p := gator.MustCompile(driver{}, gator.WithRegistry(r), gator.WithMode(gator.AllErrors))
+	err = p.ValidateLocale(src, "es-MX")
+	expected := "Name es obligatorio.\nCode necesita 1 carácter.\nTags necesita 2 caracteres.\nRadio debe ser un indicativo."
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	if err := p.ValidateLocale(src, "de"); err == nil || !strings.HasPrefix(err.Error(), "Name ist erforderlich.\nCode must") {
+		t.Errorf("expected German with an English fallback, got %v", err)
+	}
+	data := gator.MessageData{Field: "Tags", Token: "minlen", Arg: "2", Args: []string{"2"}, Value: []string{}}
+	if msg, ok := r.Message("es", data); !ok || msg != "Tags debe tener al menos 2 elementos." {
+		t.Errorf("expected a Spanish message, got %q", msg)
+	}
+	if msg, ok := r.Message("", gator.MessageData{Field: "Tags", Token: "unknown"}); ok {
+		t.Errorf("unknown tokens shouldn't have a message, got %q", msg)
+	}
+
+	for name, data := range map[string]string{
+		"a.json": `{"messages": {}}`,
+		"a.toml": "locale = \"es\"\n[messages]\nnonzero = {{.Field}}",
+		"b.json": `{"locale": "es", "messages": {"minlen": {"one": "x"}}}`,
+		"a.yaml": `locale: es`,
+	} {
+		if _, err := gator.ParseCatalog(name, []byte(data)); err == nil {
+			t.Errorf("%s should produce an error", name)
+		}
+	}
+}
+
+type quote struct {
+	Lane    string  `json:"lane" gator:"in(dry,reefer)"`
+	Weight  float64 `json:"weight" gator:"gte(1.5)"`
+	Ref     string  `json:"ref" gator:"minlen(3)"`
+	Contact string  `json:"contact" gator:"email || url"`
+	Created string  `json:"created" gator:"immutable"`
+}
+
+func TestRendering(t *testing.T) {
+	old := &quote{Created: "2016-01-02"}
+	src := &quote{Lane: "flat", Ref: "A", Contact: "x", Created: "2016-02-01"}
+	err := gator.NewChange(old, src, gator.WithFieldNamer(gator.JSONNames), gator.WithMode(gator.AllErrors)).Validate()
+	var errs gator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 5 {
+		t.Fatalf("expected 5 errors, got %v", err)
+	}
+	codes := []string{}
+	for _, fErr := range errs {
+		codes = append(codes, fErr.Code)
+	}
+	if s := strings.Join(codes, " "); s != "gator.in gator.gte gator.minlen gator.or gator.immutable" {
+		t.Errorf("unexpected codes %s", s)
+	}
+	params := []map[string]interface{}{
+		{"args": []interface{}{"dry", "reefer"}},
+		{"arg": 1.5},
+		{"arg": int64(3)},
+		{"args": []interface{}{"email", "url"}},
+		{"from": "2016-01-02", "to": "2016-02-01"},
+	}
+	for i, fErr := range errs {
+		if !reflect.DeepEqual(fErr.Params, params[i]) {
+			t.Errorf("%s: expected params %v, got %v", fErr.Field, params[i], fErr.Params)
+		}
+	}
+
+	b, jErr := json.Marshal(err)
+	if jErr != nil {
+		t.Fatal(jErr)
+	}
+	expected := `{"pointer":"/ref","field":"ref","code":"gator.minlen","params":{"arg":3},"message":"ref must have at least 3 characters."}`
+	if !strings.Contains(string(b), expected) {
+		t.Errorf("expected %s in %s", expected, b)
+	}
+
+	p := gator.NewProblem(err)
+	if p.Status != http.StatusUnprocessableEntity || p.Type != "about:blank" || len(p.Errors) != 5 {
+		t.Errorf("unexpected problem %+v", p)
+	}
+	b, jErr = json.Marshal(p)
+	if jErr != nil {
+		t.Fatal(jErr)
+	}
+	var decoded struct {
+		Status int
+		Errors []struct {
+			Pointer string
+			Code    string
+			Params  map[string]interface{}
+		}
+	}
+	if jErr := json.Unmarshal(b, &decoded); jErr != nil || decoded.Status != 422 ||
+		decoded.Errors[1].Pointer != "/weight" || decoded.Errors[1].Params["arg"] != 1.5 {
+		t.Errorf("unexpected problem+json %s", b)
+	}
+
+	v := gator.Values(err)
+	if v.Get("lane") == "" || v.Get("created") != "created can't change from 2016-01-02 to 2016-02-01." {
+		t.Errorf("unexpected values %v", v)
+	}
+
+	err = gator.NewField("Custom", 1, func(name string, v interface{}) error {
+		return errors.New("custom failure")
+	}).Validate()
+	b, _ = json.Marshal(gator.NewProblem(err))
+	if !strings.Contains(string(b), `"errors":[{"pointer":"/Custom","field":"Custom","code":"gator.invalid","message":"custom failure"}]`) {
+		t.Errorf("unexpected problem+json %s", b)
+	}
+	if p := gator.NewProblem(errors.New("not a field")); p.Errors[0].Code != "gator.invalid" || p.Errors[0].Field != "" {
+		t.Errorf("unexpected problem %+v", p.Errors[0])
+	}
+	if p := gator.NewProblem(gator.NewStruct(&struct {
+		A string `gator:"minlen("`
+	}{}).Validate()); p.Status != http.StatusInternalServerError || len(p.Errors) != 0 {
+		t.Errorf("TagErrors should be a 500, got %+v", p)
+	}
+	if gator.NewProblem(nil) != nil {
+		t.Error("a nil error should have no problem")
+	}
+}
+
+type lookupKey struct{}
+
+type booking struct {
+	Lane  string `gator:"nonzero | known_lane"`
+	Dock  string `gator:"slow_dock"`
+	Stops []stop
+}
+
+func TestContext(t *testing.T) {
+	r := gator.NewRegistry()
+	r.RegisterContext("known_lane", func(string) gator.ContextFunc {
+		return func(ctx context.Context, name string, v interface{}) error {
+			known, _ := ctx.Value(lookupKey{}).(map[string]bool)
+			if !known[v.(string)] {
+				return fmt.Errorf("%s is not a known lane.", name)
+			}
+			return nil
+		}
+	})
+	calls := int32(0)
+	r.RegisterContext("slow_dock", func(string) gator.ContextFunc {
+		return func(ctx context.Context, name string, v interface{}) error {
+			atomic.AddInt32(&calls, 1)
+			if v == "slow" {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		}
+	})
+	ctx := context.WithValue(context.Background(), lookupKey{}, map[string]bool{"HOU-DAL": true})
+	options := []func(*gator.Gator){gator.WithRegistry(r), gator.WithMode(gator.AllErrors)}
+
+	if err := gator.NewStruct(&booking{Lane: "HOU-DAL"}, options...).ValidateContext(ctx); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	err := gator.NewStruct(&booking{Lane: "HOU-DAL"}, options...).Validate()
+	if err == nil || err.Error() != "Lane is not a known lane." {
+		t.Errorf("Validate should pass a background context, got %v", err)
+	}
+
+	deadline, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	src := &booking{Lane: "AUS-DAL", Dock: "slow", Stops: []stop{{"1"}}}
+	err = gator.NewStruct(src, options...).Add(gator.NewField("Extra", "", gator.Nonzero())).ValidateContext(deadline)
+	var cErr *gator.ContextError
+	if !errors.As(err, &cErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a ContextError, got %v", err)
+	}
+	if err := gator.MustCompile(src, options...).ValidateContext(deadline, src); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Plans to stop, got %v", err)
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	atomic.StoreInt32(&calls, 0)
+	err = gator.New().Add(gator.NewStruct(src, options...)).ValidateContext(canceled)
+	if !errors.Is(err, context.Canceled) || atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("expected validation to stop before any token, got %v after %d calls", err, calls)
+	}
+	if err != nil && err.Error() != "gator: validation stopped - context canceled" {
+		t.Errorf("unexpected message %s", err)
+	}
+}
+
+type carrierRef struct {
+	DOT int `gator:"exists(carriers.dot)"`
+}
+
+type fleet struct {
+	Partners []carrierRef
+}
+
+type registration struct {
+	Username string       `gator:"nonzero | unique(users.username)"`
+	DOT      int          `gator:"exists(carriers.dot)"`
+	Backups  []int        `gator:"each(exists(carriers.dot))"`
+	Email    string       `gator:"email"`
+	Partners []carrierRef `gator:"maxlen(10)"`
+}
+
+func TestLookups(t *testing.T) {
+	res := gator.NewMemoryResolver()
+	res.Add("carriers.dot", 123, 456)
+	res.Add("users.username", "taken")
+	options := []func(*gator.Gator){gator.WithResolver(res), gator.WithMode(gator.AllErrors)}
+
+	src := &registration{Username: "new", DOT: 123, Backups: []int{456}, Email: "a@b.co", Partners: []carrierRef{{123}, {0}}}
+	if err := gator.NewStruct(src, options...).Validate(); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	src = &registration{Username: "taken", DOT: 7, Backups: []int{456, 8}, Email: "a", Partners: []carrierRef{{9}, {123}}}
+	expected := "Username is already taken.\nDOT was not found.\nEvery element of Backups must pass exists(carriers.dot).\nEmail must be a valid email address.\nPartners[0].DOT was not found."
+	if err := gator.NewStruct(src, options...).Validate(); err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	if err := gator.NewStruct(src, gator.WithResolver(res)).Validate(); err == nil || err.Error() != "Username is already taken." {
+		t.Errorf("expected the first error in field order, got %v", err)
+	}
+	res.Remove("users.username", "taken")
+	if err := gator.NewStruct(src, gator.WithResolver(res)).Validate(); err == nil || err.Error() != "DOT was not found." {
+		t.Errorf("expected removed values to be gone, got %v", err)
+	}
+	if err := gator.NewStruct(src).Validate(); err == nil || !strings.Contains(err.Error(), "without a Resolver") {
+		t.Errorf("expected lookups to fail without a Resolver, got %v", err)
+	}
+
+	var inFlight, maxInFlight, calls int32
+	slow := gator.ResolverFunc(func(ctx context.Context, source string, value interface{}) (bool, error) {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		select {
+		case <-time.After(5 * time.Millisecond):
+			return value != 0, nil
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	})
+	partners := &fleet{Partners: make([]carrierRef, 10)}
+	for i := range partners.Partners {
+		partners.Partners[i].DOT = i % 3
+	}
+	p := gator.MustCompile(partners, gator.WithResolver(slow), gator.WithLookupWorkers(2), gator.WithMode(gator.AllErrors))
+	if err := p.Validate(partners); err != nil || atomic.LoadInt32(&calls) != 6 {
+		t.Errorf("expected 6 lookups without errors, got %d: %v", calls, err)
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max > 2 {
+		t.Errorf("expected at most 2 lookups at once, got %d", max)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	cached := gator.NewCachingResolver(slow, time.Hour)
+	p = gator.MustCompile(partners, gator.WithResolver(cached), gator.WithLookupWorkers(5), gator.WithMode(gator.AllErrors))
+	for i := 0; i < 3; i++ {
+		if err := p.Validate(partners); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected 2 lookups with a cache, got %d", n)
+	}
+	atomic.StoreInt32(&calls, 0)
+	expiring := gator.NewCachingResolver(slow, 100*time.Millisecond)
+	p = gator.MustCompile(partners, gator.WithResolver(expiring), gator.WithLookupWorkers(1))
+	p.Validate(partners)
+	time.Sleep(150 * time.Millisecond)
+	p.Validate(partners)
+	if n := atomic.LoadInt32(&calls); n != 4 {
+		t.Errorf("expected expired results to be looked up again, got %d lookups", n)
+	}
+
+	var panics int32
+	panicky := gator.NewCachingResolver(gator.ResolverFunc(func(ctx context.Context, source string, value interface{}) (bool, error) {
+		atomic.AddInt32(&panics, 1)
+		panic("connection reset")
+	}), time.Hour)
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := gator.NewStruct(&carrierRef{DOT: 1}, gator.WithResolver(panicky)).ValidateContext(ctx)
+		cancel()
+		if err == nil || !strings.HasSuffix(err.Error(), "gator: Resolver panicked - connection reset") {
+			t.Errorf("expected the panic to be reported, got %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&panics); n != 2 {
+		t.Errorf("expected lookups that panicked to be retried, got %d lookups", n)
+	}
+
+	var shared int32
+	started := make(chan struct{})
+	leader := gator.NewCachingResolver(gator.ResolverFunc(func(ctx context.Context, source string, value interface{}) (bool, error) {
+		if atomic.AddInt32(&shared, 1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return false, ctx.Err()
+		}
+		return true, nil
+	}), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	go leader.Exists(ctx, "carriers.dot", 1)
+	<-started
+	errc := make(chan error)
+	go func() {
+		errc <- gator.NewStruct(&carrierRef{DOT: 1}, gator.WithResolver(leader)).Validate()
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-errc; err != nil {
+		t.Errorf("a canceled lookup shouldn't fail the lookups sharing it, got %v", err)
+	}
+
+	blocked := gator.ResolverFunc(func(ctx context.Context, source string, value interface{}) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	err := gator.NewStruct(&carrierRef{DOT: 1}, gator.WithResolver(blocked), gator.WithLookupTimeout(time.Millisecond)).Validate()
+	var fErr *gator.FieldError
+	if !errors.As(err, &fErr) || fErr.Field != "DOT" || !strings.HasSuffix(err.Error(), "context deadline exceeded") {
+		t.Errorf("expected the lookup to time out, got %v", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err = gator.NewStruct(&carrierRef{DOT: 1}, gator.WithResolver(blocked)).ValidateContext(ctx)
+	var cErr *gator.ContextError
+	if !errors.As(err, &cErr) {
+		t.Errorf("expected a ContextError, got %v", err)
+	}
+}
+
+type axle struct {
+	Weight int `json:"axle_weight" gator:"share_of(Total,50)"`
+}
+
+type freight struct {
+	Total int
+	Axles []axle
+	Units string `unit:"lb" gator:"described"`
+	Count int    `gator:"min_count(2) | positive"`
+}
+
+func TestFieldFuncs(t *testing.T) {
+	r := gator.NewRegistry()
+	var seen []*gator.FieldContext
+	r.RegisterFieldFunc("share_of", func(fc *gator.FieldContext) error {
+		seen = append(seen, fc)
+		total, ok := fc.Root.FieldByName(fc.Args[0]).Interface().(int)
+		percent, _ := strconv.Atoi(fc.Args[1])
+		if !ok || fc.Value.(int)*100 > total*percent {
+			return fmt.Errorf("%s must be at most %d%% of %s.", fc.Name, percent, fc.Args[0])
+		}
+		return nil
+	})
+	r.RegisterFieldFunc("described", func(fc *gator.FieldContext) error {
+		if fc.Value != fc.Field.Tag.Get("unit") {
+			return fmt.Errorf("%s must be %s.", fc.Name, fc.Field.Tag.Get("unit"))
+		}
+		if count, ok := fc.Sibling("Count"); !ok || count.(int) < 0 {
+			return fmt.Errorf("%s needs a Count.", fc.Name)
+		}
+		if _, ok := fc.Sibling("Missing"); ok {
+			return errors.New("Sibling should fail for missing fields")
+		}
+		return nil
+	})
+	r.RegisterFieldFunc("min_count", gator.AdaptFunc(gator.Gte(2)))
+	r.RegisterFieldFunc("positive", gator.AdaptContextFunc(func(ctx context.Context, name string, v interface{}) error {
+		if ctx.Value(lookupKey{}) != nil && v.(int) <= 0 {
+			return fmt.Errorf("%s must be positive.", name)
+		}
+		return nil
+	}))
+	options := []func(*gator.Gator){gator.WithRegistry(r), gator.WithMode(gator.AllErrors)}
+
+	src := &freight{Total: 100, Axles: []axle{{40}, {60}}, Units: "kg", Count: 1}
+	err := gator.NewStruct(src, options...).Validate()
+	expected := "Axles[1].Weight must be at most 50% of Total.\nUnits must be lb.\nCount did not pass validation."
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected share_of to run twice, ran %d times", len(seen))
+	}
+	fc := seen[1]
+	if fc.Token != "share_of" || fc.Registry != r || fc.Path.String() != "Axles[1].Weight" || fc.Field.Name != "Weight" ||
+		fc.Field.Tag.Get("json") != "axle_weight" || fc.Parent.Type() != reflect.TypeOf(axle{}) || fc.Root.Type() != reflect.TypeOf(freight{}) {
+		t.Errorf("unexpected FieldContext %+v", fc)
+	}
+	if fc.Context() != context.Background() {
+		t.Error("Validate should pass a background context")
+	}
+
+	src = &freight{Total: 100, Units: "lb", Count: -1}
+	ctx := context.WithValue(context.Background(), lookupKey{}, true)
+	err = gator.NewStruct(src, options...).ValidateContext(ctx)
+	expected = "Units needs a Count.\nCount did not pass validation.\nCount must be positive."
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+}
+
+type stopWeight struct {
+	Weight int `gator:"gte(0)"`
+}
+
+type seal struct {
+	Number string
+}
+
+func (s *seal) Validate() error {
+	if len(s.Number) != 6 {
+		return errors.New("Seal numbers have 6 digits.")
+	}
+	return nil
+}
+
+type yard struct {
+	Door  int
+	Doors int
+}
+
+func (y yard) GatorValidate() error {
+	if y.Door > y.Doors {
+		return &gator.FieldError{Field: "Door", Err: errors.New("Door must be one of the yard's doors.")}
+	}
+	return nil
+}
+
+type audited struct {
+	calls *int
+}
+
+func (a audited) GatorValidate() error {
+	*a.calls++
+	return nil
+}
+
+type manifest struct {
+	audited
+	TotalWeight int
+	Stops       []stopWeight
+	Dest        yard
+	Seals       map[string]seal
+}
+
+type sealedTrailer struct {
+	Seal seal
+}
+
+func (t *sealedTrailer) Validate() error {
+	return gator.NewStruct(t).Validate()
+}
+
+type dockZip struct {
+	Zip string `gator:"len(5)"`
+}
+
+func (z dockZip) Validate() error {
+	return gator.NewStruct(z, gator.WithMode(gator.AllErrors)).Validate()
+}
+
+type dock struct {
+	Name   string `gator:"nonzero"`
+	Origin dockZip
+}
+
+func TestStructValidation(t *testing.T) {
+	r := gator.NewRegistry()
+	r.RegisterStructValidation(&manifest{}, func(ctx context.Context, v interface{}) error {
+		m := v.(manifest)
+		sum := 0
+		for _, s := range m.Stops {
+			sum += s.Weight
+		}
+		if sum != m.TotalWeight {
+			return fmt.Errorf("Stops weigh %d, not %d.", sum, m.TotalWeight)
+		}
+		if ctx.Value(lookupKey{}) != nil {
+			return gator.ValidationErrors{{Path: gator.Path{{Field: "TotalWeight"}}, Err: errors.New("TotalWeight is locked.")}}
+		}
+		return nil
+	})
+	r.RegisterStructValidation(stopWeight{}, func(ctx context.Context, v interface{}) error {
+		if v.(stopWeight).Weight > 1000 {
+			panic("overweight")
+		}
+		return nil
+	})
+	calls := 0
+	src := &manifest{
+		audited:     audited{&calls},
+		TotalWeight: 10,
+		Stops:       []stopWeight{{4}, {-1}},
+		Dest:        yard{Door: 3, Doors: 2},
+		Seals:       map[string]seal{"rear": {"123"}, "side": {"123456"}},
+	}
+	options := []func(*gator.Gator){gator.WithRegistry(r), gator.WithMode(gator.AllErrors)}
+	err := gator.NewStruct(src, options...).Validate()
+	var errs gator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", err)
+	}
+	fields := []string{"Stops[1].Weight", "Dest.Door", "Seals[rear]", ""}
+	messages := []string{"Stops[1].Weight must be greater than or equal to 0.", "Door must be one of the yard's doors.", "Seal numbers have 6 digits.", "Stops weigh 3, not 10."}
+	for i, fErr := range errs {
+		if fErr.Field != fields[i] || fErr.Error() != messages[i] {
+			t.Errorf("expected %q at %q, got %q at %q", messages[i], fields[i], fErr.Error(), fErr.Field)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected GatorValidate of the embedded struct to be called once, got %d", calls)
+	}
+
+	src.Stops[1].Weight = 6
+	src.Dest.Door = 1
+	src.Seals = nil
+	ctx := context.WithValue(context.Background(), lookupKey{}, true)
+	err = gator.NewStruct(src, options...).ValidateContext(ctx)
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "TotalWeight" || errs[0].Code != "gator.invalid" {
+		t.Errorf("expected TotalWeight to be locked, got %v", err)
+	}
+	if err := gator.NewStruct(src, options...).Validate(); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	if err := gator.NewStruct(src, append(options, gator.OnlyFields("Dest"))...).Validate(); err != nil {
+		t.Errorf("struct level validations should be skipped by OnlyFields, got %s", err)
+	}
+	src.Stops[0].Weight = 1001
+	err = gator.NewStruct(src, options...).Validate()
+	if err == nil || !strings.HasPrefix(err.Error(), "gator: struct level validation of gator_test.stopWeight panicked - overweight") {
+		t.Errorf("expected panics to be recovered, got %v", err)
+	}
+
+	trailer := &sealedTrailer{Seal: seal{"1"}}
+	if err := trailer.Validate(); err == nil || err.Error() != "Seal numbers have 6 digits." {
+		t.Errorf("expected the nested Validate method to be called, got %v", err)
+	}
+	if err := gator.NewStruct(&sealedTrailer{Seal: seal{"123456"}}).Validate(); err != nil {
+		t.Errorf("expected no error, got %s", err)
+	}
+	err = gator.NewStruct(&dock{Origin: dockZip{"1"}}, gator.WithMode(gator.AllErrors)).Validate()
+	if expected := "Name is required.\nOrigin.Zip must have exactly 5 characters."; err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+}
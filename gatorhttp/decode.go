@@ -0,0 +1,98 @@
+package gatorhttp
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// decodeValues sets the fields of v, a struct, from values.  Fields are
+// named by their form tags or their Go names if they have none.  The
+// fields of embedded structs are promoted.  Strings, booleans, numbers,
+// encoding.TextUnmarshalers and pointers and slices of them can be
+// decoded.  Fields missing from values are left as they are.
+func decodeValues(values url.Values, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fv := v.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := decodeValues(values, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		name := strings.Split(f.Tag.Get("form"), ",")[0]
+		switch {
+		case f.PkgPath != "" || name == "-":
+			continue
+		case name == "":
+			name = f.Name
+		}
+		vals := values[name]
+		if len(vals) == 0 {
+			continue
+		}
+		if !setValue(fv, vals) {
+			return fmt.Errorf("gatorhttp: invalid value %q for %s", strings.Join(vals, ","), name)
+		}
+	}
+	return nil
+}
+
+// setValue sets v from vals and reports whether they could be parsed.
+// Only the first of vals is used unless v is a slice.
+func setValue(v reflect.Value, vals []string) bool {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setValue(v.Elem(), vals)
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(vals[0])) == nil
+	}
+	s := vals[0]
+	switch v.Kind() {
+	case reflect.Slice:
+		elems := reflect.MakeSlice(v.Type(), len(vals), len(vals))
+		for i := range vals {
+			if !setValue(elems.Index(i), vals[i:i+1]) {
+				return false
+			}
+		}
+		v.Set(elems)
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return false
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return false
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return false
+		}
+		v.SetFloat(f)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,224 @@
+// Package gatorhttp binds HTTP requests to structs and validates them
+// with gator.
+//
+// Bind decodes a request into a struct and runs its gator tags:
+//
+//	type tenderRequest struct {
+//	    ShipperRef string `json:"shipper_ref" form:"ref" gator:"nonzero"`
+//	    Weight     int    `json:"weight" form:"weight" gator:"gt(0)"`
+//	}
+//
+//	func createTender(w http.ResponseWriter, r *http.Request) {
+//	    var req tenderRequest
+//	    if err := gatorhttp.Bind(r, &req); err != nil {
+//	        gatorhttp.WriteError(w, err)
+//	        return
+//	    }
+//	    ...
+//	}
+//
+// Requests that can't be decoded are answered with 400 Bad Request and
+// those that don't pass validation with 422 Unprocessable Entity, both
+// as application/problem+json documents.  See gator.NewProblem.
+package gatorhttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ShaleApps/gator"
+)
+
+// An Error is returned by Bind when a request can't be decoded or
+// doesn't pass validation.
+type Error struct {
+	// Status is the HTTP status the error is reported with: 400 Bad
+	// Request, 413 Request Entity Too Large or 415 Unsupported Media
+	// Type if the request couldn't be decoded, 422 Unprocessable Entity
+	// if it didn't pass validation, 500 Internal Server Error if the
+	// destination's gator tags are broken and 503 Service Unavailable if
+	// the request's context was done before validation finished.
+	Status int
+	// Err is the decoding error or the error returned from Validate.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the decoding or validation error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Bind decodes r into dst, which must be a non-nil pointer to a struct,
+// and validates it using its gator tags.  The query string is decoded
+// for GET, HEAD and DELETE requests and the body for others: JSON
+// bodies using encoding/json and URL encoded or multipart forms by the
+// names in the form tags of dst's fields.  Errors are reported with
+// JSONNames for JSON bodies and FormNames otherwise, every error is
+// reported, messages are localized by the request's Accept-Language
+// header and the request's context is passed to ValidateContext.
+// options are applied after these defaults.  Problems with the request
+// are returned as an *Error.
+func Bind(r *http.Request, dst interface{}, options ...func(*gator.Gator)) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("gatorhttp: dst must be a non-nil pointer to a struct")
+	}
+	namer, err := decode(r, v.Elem())
+	if err != nil {
+		return err
+	}
+	defaults := []func(*gator.Gator){
+		gator.WithFieldNamer(namer),
+		gator.WithMode(gator.AllErrors),
+		gator.WithLocale(acceptLanguage(r)),
+	}
+	err = gator.NewStruct(dst, append(defaults, options...)...).ValidateContext(r.Context())
+	var tErrs gator.TagErrors
+	var cErr *gator.ContextError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &tErrs):
+		return &Error{Status: http.StatusInternalServerError, Err: err}
+	case errors.As(err, &cErr):
+		return &Error{Status: http.StatusServiceUnavailable, Err: err}
+	}
+	return &Error{Status: http.StatusUnprocessableEntity, Err: err}
+}
+
+// decode decodes r into v, a struct, and returns the FieldNamer that
+// names its fields as the request did.
+func decode(r *http.Request, v reflect.Value) (*gator.FieldNamer, error) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return gator.FormNames, badRequest(decodeValues(r.URL.Query(), v))
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case err != nil:
+		return nil, &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("gatorhttp: request has no valid Content-Type")}
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		err := json.NewDecoder(r.Body).Decode(v.Addr().Interface())
+		if err == io.EOF {
+			err = errors.New("gatorhttp: request body is empty")
+		}
+		return gator.JSONNames, badRequest(err)
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, badRequest(err)
+		}
+		return gator.FormNames, badRequest(decodeValues(r.Form, v))
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, badRequest(err)
+		}
+		return gator.FormNames, badRequest(decodeValues(r.Form, v))
+	}
+	return nil, &Error{Status: http.StatusUnsupportedMediaType, Err: errors.New("gatorhttp: can't decode " + mediaType)}
+}
+
+// badRequest wraps err, an error decoding a request, in an *Error.
+// Bodies larger than allowed by http.MaxBytesReader are reported as
+// 413 Request Entity Too Large.
+func badRequest(err error) error {
+	if err == nil {
+		return nil
+	}
+	var mbErr *http.MaxBytesError
+	if errors.As(err, &mbErr) {
+		return &Error{Status: http.StatusRequestEntityTooLarge, Err: err}
+	}
+	return &Error{Status: http.StatusBadRequest, Err: err}
+}
+
+// acceptLanguage returns the first language in r's Accept-Language
+// header.
+func acceptLanguage(r *http.Request) string {
+	lang := strings.Split(r.Header.Get("Accept-Language"), ",")[0]
+	lang = strings.TrimSpace(strings.Split(lang, ";")[0])
+	if lang == "*" {
+		return ""
+	}
+	return lang
+}
+
+// WriteError writes err as an application/problem+json document.  An
+// *Error is written with its Status: failed validations list every
+// FieldError as described by gator.NewProblem and decoding errors are
+// described in the detail member.  Other errors are written as 500
+// Internal Server Error without revealing them.
+func WriteError(w http.ResponseWriter, err error) {
+	var bErr *Error
+	var p *gator.Problem
+	switch {
+	case !errors.As(err, &bErr):
+		p = problem(http.StatusInternalServerError, "")
+	case bErr.Status == http.StatusUnprocessableEntity:
+		p = gator.NewProblem(bErr.Err)
+	case bErr.Status >= http.StatusInternalServerError:
+		p = problem(bErr.Status, "")
+	default:
+		p = problem(bErr.Status, bErr.Err.Error())
+	}
+	w.Header().Set("Content-Type", gator.ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+func problem(status int, detail string) *gator.Problem {
+	return &gator.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Errors: gator.ValidationErrors{},
+	}
+}
+
+// A HandlerFunc is an http.Handler that returns an error.  Errors are
+// written with WriteError, so handlers can return the error from Bind.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP calls f(w, r) and writes the error it returns, if any.
+func (f HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f(w, r); err != nil {
+		WriteError(w, err)
+	}
+}
+
+type contextKey struct{}
+
+// Middleware returns middleware that binds every request to a value
+// returned by newDst, a pointer to a struct, using Bind.  The value is
+// passed to the next handler in the request's context, see Bound.
+// Requests that can't be bound are answered using WriteError.
+func Middleware(newDst func() interface{}, options ...func(*gator.Gator)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dst := newDst()
+			if err := Bind(r, dst, options...); err != nil {
+				WriteError(w, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, dst)))
+		})
+	}
+}
+
+// Bound returns the value bound to r by Middleware or nil if there is
+// none.
+func Bound(r *http.Request) interface{} {
+	return r.Context().Value(contextKey{})
+}
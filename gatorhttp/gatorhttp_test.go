@@ -0,0 +1,204 @@
+package gatorhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShaleApps/gator"
+	"github.com/ShaleApps/gator/gatorhttp"
+)
+
+type Audit struct {
+	Note string `json:"note" form:"note"`
+}
+
+type tenderRequest struct {
+	Audit
+	ShipperRef string    `json:"shipper_ref" form:"ref" gator:"nonzero"`
+	Weight     int       `json:"weight" form:"weight" gator:"gt(0)"`
+	Pickup     time.Time `json:"pickup" form:"pickup"`
+	Hazmat     *bool     `json:"hazmat" form:"hazmat"`
+	Stops      []string  `json:"stops" form:"stop" gator:"minlen(1)"`
+}
+
+func request(method, target, contentType, body string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	return r
+}
+
+func TestBind(t *testing.T) {
+	form := url.Values{"ref": {"A1"}, "weight": {"10"}, "pickup": {"2016-01-02T15:04:05Z"}, "hazmat": {"true"}, "stop": {"a", "b"}, "note": {"n"}}
+	requests := []*http.Request{
+		request("POST", "/", "application/json; charset=utf-8",
+			`{"shipper_ref":"A1","weight":10,"pickup":"2016-01-02T15:04:05Z","hazmat":true,"stops":["a","b"],"note":"n"}`),
+		request("PUT", "/", "application/x-www-form-urlencoded", form.Encode()),
+		request("GET", "/?"+form.Encode(), "", ""),
+	}
+	for _, r := range requests {
+		var dst tenderRequest
+		if err := gatorhttp.Bind(r, &dst); err != nil {
+			t.Errorf("%s %s: unexpected error %s", r.Method, r.Header.Get("Content-Type"), err)
+			continue
+		}
+		pickup := time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC)
+		if dst.ShipperRef != "A1" || dst.Weight != 10 || !dst.Pickup.Equal(pickup) || dst.Hazmat == nil || !*dst.Hazmat ||
+			len(dst.Stops) != 2 || dst.Note != "n" {
+			t.Errorf("%s: unexpected result %+v", r.Method, dst)
+		}
+	}
+
+	tests := []struct {
+		r      *http.Request
+		status int
+		err    string
+	}{
+		{request("POST", "/", "application/json", `{"weight":-1}`), http.StatusUnprocessableEntity,
+			"shipper_ref is required.\nweight must be greater than 0.\nstops must have at least 1 item."},
+		{request("GET", "/?weight=1&stop=a", "", ""), http.StatusUnprocessableEntity, "ref is required."},
+		{request("GET", "/?weight=heavy", "", ""), http.StatusBadRequest, `gatorhttp: invalid value "heavy" for weight`},
+		{request("POST", "/", "application/json", `{"weight":"heavy"}`), http.StatusBadRequest, ""},
+		{request("POST", "/", "application/json", ``), http.StatusBadRequest, "gatorhttp: request body is empty"},
+		{request("POST", "/", "text/plain", `ref=A1`), http.StatusUnsupportedMediaType, "gatorhttp: can't decode text/plain"},
+		{request("POST", "/", "", `ref=A1`), http.StatusUnsupportedMediaType, "gatorhttp: request has no valid Content-Type"},
+	}
+	for _, test := range tests {
+		var bErr *gatorhttp.Error
+		err := gatorhttp.Bind(test.r, &tenderRequest{})
+		switch {
+		case !errors.As(err, &bErr):
+			t.Errorf("expected an *Error, got %v", err)
+		case bErr.Status != test.status:
+			t.Errorf("expected status %d, got %d: %s", test.status, bErr.Status, err)
+		case test.err != "" && err.Error() != test.err:
+			t.Errorf("expected:\n%s\ngot:\n%s", test.err, err)
+		}
+	}
+
+	r := request("GET", "/?weight=1&stop=a", "", "")
+	r.Header.Set("Accept-Language", "es-MX,es;q=0.9,en;q=0.8")
+	if err := gatorhttp.Bind(r, &tenderRequest{}); err == nil || err.Error() != "ref es obligatorio." {
+		t.Errorf("expected a Spanish message, got %v", err)
+	}
+	r = request("GET", "/?weight=1&stop=a", "", "")
+	if err := gatorhttp.Bind(r, &tenderRequest{}, gator.WithFieldNamer(gator.GoNames)); err == nil || err.Error() != "ShipperRef is required." {
+		t.Errorf("options should override the defaults, got %v", err)
+	}
+	r = request("POST", "/", "application/json", strings.Repeat(" ", 100))
+	r.Body = http.MaxBytesReader(httptest.NewRecorder(), r.Body, 10)
+	var bErr *gatorhttp.Error
+	if err := gatorhttp.Bind(r, &tenderRequest{}); !errors.As(err, &bErr) || bErr.Status != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %v", err)
+	}
+	if err := gatorhttp.Bind(request("GET", "/", "", ""), tenderRequest{}); err == nil || errors.As(err, &bErr) {
+		t.Errorf("expected a plain error for a non-pointer destination, got %v", err)
+	}
+}
+
+type problem struct {
+	Type   string
+	Title  string
+	Status int
+	Detail string
+	Errors []struct {
+		Pointer string
+		Code    string
+		Message string
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	handler := gatorhttp.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		var dst tenderRequest
+		if err := gatorhttp.Bind(r, &dst); err != nil {
+			return err
+		}
+		if dst.ShipperRef == "boom" {
+			return errors.New("database is down")
+		}
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+	tests := []struct {
+		r       *http.Request
+		status  int
+		detail  string
+		pointer []string
+	}{
+		{request("POST", "/", "application/json", `{"shipper_ref":"A1","weight":1,"stops":["a"]}`), http.StatusCreated, "", nil},
+		{request("POST", "/", "application/json", `{"stops":[]}`), http.StatusUnprocessableEntity,
+			"The request did not pass validation.", []string{"/shipper_ref", "/weight", "/stops"}},
+		{request("POST", "/", "application/json", `{`), http.StatusBadRequest, "unexpected EOF", []string{}},
+		{request("POST", "/", "application/json", `{"shipper_ref":"boom","weight":1,"stops":["a"]}`),
+			http.StatusInternalServerError, "", []string{}},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, test.r)
+		if w.Code != test.status {
+			t.Errorf("expected status %d, got %d", test.status, w.Code)
+			continue
+		}
+		if test.pointer == nil {
+			continue
+		}
+		if ct := w.Header().Get("Content-Type"); ct != gator.ProblemContentType {
+			t.Errorf("expected %s, got %s", gator.ProblemContentType, ct)
+		}
+		var p problem
+		if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+			t.Fatal(err)
+		}
+		pointers := []string{}
+		for _, e := range p.Errors {
+			pointers = append(pointers, e.Pointer)
+		}
+		if p.Status != test.status || p.Title != http.StatusText(test.status) || p.Detail != test.detail ||
+			fmt.Sprint(pointers) != fmt.Sprint(test.pointer) {
+			t.Errorf("unexpected problem %s", w.Body)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	mw := gatorhttp.Middleware(func() interface{} { return &tenderRequest{} })
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, gatorhttp.Bound(r).(*tenderRequest).ShipperRef)
+	}))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.PostForm(srv.URL, url.Values{"ref": {"A1"}, "weight": {"3"}, "stop": {"a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := make([]byte, 2)
+	resp.Body.Read(b)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(b) != "A1" {
+		t.Errorf("expected A1, got %d %s", resp.StatusCode, b)
+	}
+
+	resp, err = http.Get(srv.URL + "?ref=A1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var p problem
+	json.NewDecoder(resp.Body).Decode(&p)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity || len(p.Errors) != 2 || p.Errors[0].Code != "gator.gt" {
+		t.Errorf("unexpected response %d %+v", resp.StatusCode, p)
+	}
+	if gatorhttp.Bound(httptest.NewRequest("GET", "/", nil)) != nil {
+		t.Error("requests that weren't bound should hold nil")
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/ShaleApps/gator
+
+go 1.20
@@ -0,0 +1,147 @@
+package gator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// MessageData is passed to the text/template message of a token when
+// a field fails it.
+type MessageData struct {
+	// Field is the name of the field that failed.
+	Field string
+	// Token is the token that failed, e.g. "minlen".
+	Token string
+	// Arg is the argument supplied to Token, e.g. "5" for "minlen(5)".
+	Arg string
+	// Args holds each comma separated argument supplied to Token.
+	Args []string
+	// Value is the offending value.
+	Value interface{}
+}
+
+// messageFuncs are available to message templates in addition to the
+// text/template built-ins.
+var messageFuncs = template.FuncMap{
+	"join": strings.Join,
+	// isString reports whether the length of v counts characters.
+	"isString": func(v interface{}) bool {
+		_, ok := toString(v)
+		return ok
+	},
+	// units describes what the length of v counts in English.
+	"units": func(v interface{}) string {
+		if _, ok := toString(v); ok {
+			return "characters"
+		}
+		return "items"
+	},
+}
+
+func parseMessage(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(messageFuncs).Parse(text)
+}
+
+// messageForms holds the plural forms of a message keyed by CLDR plural
+// category: "zero", "one", "two", "few", "many" and "other".  Every
+// message has an "other" form.
+type messageForms map[string]*template.Template
+
+// parseForms parses the plural forms of the message of token.
+func parseForms(token string, forms map[string]string) (messageForms, error) {
+	if _, ok := forms["other"]; !ok {
+		return nil, errors.New("gator: message for " + token + " has no \"other\" form")
+	}
+	m := messageForms{}
+	for category, text := range forms {
+		tmpl, err := parseMessage(token, text)
+		if err != nil {
+			return nil, err
+		}
+		m[category] = tmpl
+	}
+	return m, nil
+}
+
+// render executes the form chosen by the numerical argument of the
+// token, if any, in locale.
+func (m messageForms) render(locale string, data MessageData) (string, error) {
+	tmpl := m["other"]
+	if n, err := strconv.ParseFloat(data.Arg, 64); err == nil {
+		if form, ok := m[pluralCategory(locale, n)]; ok {
+			tmpl = form
+		}
+	}
+	return execute(tmpl, data)
+}
+
+func execute(tmpl *template.Template, data MessageData) (string, error) {
+	b := strings.Builder{}
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// pluralCategory returns the CLDR plural category of n in locale.  Only
+// the rules of common languages are known, others use English's.
+func pluralCategory(locale string, n float64) string {
+	switch baseLocale(locale) {
+	case "ja", "ko", "zh", "th", "vi", "id":
+		return "other"
+	case "fr", "pt":
+		if n >= 0 && n < 2 {
+			return "one"
+		}
+		return "other"
+	}
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// failedError is returned by Funcs that don't describe why a value
+// failed.  It is replaced by the message of the token that failed.
+type failedError struct {
+	name string
+}
+
+func (e *failedError) Error() string {
+	return e.name + " did not pass validation."
+}
+
+// message returns the error reported when the rule's check fails with
+// err.  The field's message replaces any error.  Token messages only
+// replace failedErrors; the message from the Registry's Catalog for the
+// locale is used if there is one, otherwise the Registry's own message.
+// err is returned as is if no message can be rendered.
+func (r rule) message(fc *fieldContext, err error) error {
+	data := MessageData{Field: fc.name, Token: r.token, Arg: r.arg, Args: r.args, Value: fc.value}
+	if r.fieldMsg != nil {
+		if s, mErr := execute(r.fieldMsg, data); mErr == nil {
+			return errors.New(s)
+		}
+		return err
+	}
+	var fErr *failedError
+	if !errors.As(err, &fErr) {
+		return err
+	}
+	forms := r.tokenMsg
+	if fc.reg != nil && fc.locale != "" {
+		if m, ok := fc.reg.localized(fc.locale, r.token); ok {
+			forms = m
+		}
+	}
+	if forms == nil {
+		return err
+	}
+	s, mErr := forms.render(fc.locale, data)
+	if mErr != nil {
+		return err
+	}
+	return errors.New(s)
+}
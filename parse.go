@@ -0,0 +1,479 @@
+package gator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The gator tag grammar:
+//
+//	tag    = group { ";" group }
+//	group  = [ ident { "," ident } ":" ] [ expr ]
+//	expr   = and { "||" and }
+//	and    = unary { "|" unary }
+//	unary  = "!" unary | "(" expr ")" | term
+//	term   = ident [ "(" args ")" ]
+//	args   = arg { "," arg }
+//	arg    = quoted | bare
+//
+// A quoted argument is wrapped in single or double quotes and may use
+// the escape sequences \\ \' \" \n \r \t \| \, \( and \).  A bare
+// argument is taken verbatim; parentheses inside it must balance and a
+// backslash stops the following character from being treated as a
+// delimiter.  Whitespace around tokens and arguments is ignored.
+
+// node is an element of a parsed gator tag.
+type node interface {
+	column() int
+	// source returns the text the node was parsed from.
+	source() string
+}
+
+// andNode is a list of nodes that must all pass, written "a | b".
+type andNode struct {
+	col   int
+	text  string
+	nodes []node
+}
+
+func (n *andNode) column() int    { return n.col }
+func (n *andNode) source() string { return n.text }
+
+// orNode is a list of nodes of which one must pass, written "a || b".
+type orNode struct {
+	col   int
+	text  string
+	nodes []node
+}
+
+func (n *orNode) column() int    { return n.col }
+func (n *orNode) source() string { return n.text }
+
+// notNode is a node that must fail, written "!a".
+type notNode struct {
+	col  int
+	text string
+	node node
+}
+
+func (n *notNode) column() int    { return n.col }
+func (n *notNode) source() string { return n.text }
+
+// tokenNode is a single token such as "minlen(5)".
+type tokenNode struct {
+	col    int
+	text   string
+	name   string
+	paren  bool     // parentheses were present
+	raw    string   // raw text between the parentheses
+	rawCol int      // column of the first character of raw
+	args   []string // decoded, comma separated arguments
+}
+
+func (n *tokenNode) column() int    { return n.col }
+func (n *tokenNode) source() string { return n.text }
+
+// arg returns the argument passed to tokens registered with
+// RegisterStructTagToken.  A single argument is returned decoded,
+// otherwise the raw text between the parentheses is returned.
+func (n *tokenNode) arg() string {
+	if len(n.args) == 1 {
+		return n.args[0]
+	}
+	return n.raw
+}
+
+// parseArg parses the raw argument of n as a nested expression.
+// Columns are relative to the tag n was parsed from.
+func (n *tokenNode) parseArg() (node, error) {
+	offset := n.rawCol - 1
+	expr, err := parseTag(n.raw)
+	if err != nil {
+		tErr := err.(*TagError)
+		tErr.Col += offset
+		return nil, tErr
+	}
+	shiftColumns(expr, offset)
+	return expr, nil
+}
+
+func shiftColumns(nd node, offset int) {
+	switch n := nd.(type) {
+	case *andNode:
+		n.col += offset
+		for _, child := range n.nodes {
+			shiftColumns(child, offset)
+		}
+	case *orNode:
+		n.col += offset
+		for _, child := range n.nodes {
+			shiftColumns(child, offset)
+		}
+	case *notNode:
+		n.col += offset
+		shiftColumns(n.node, offset)
+	case *tokenNode:
+		n.col += offset
+		n.rawCol += offset
+	}
+}
+
+// groupNode is an expression that applies to the named validation
+// groups.  names is empty if no groups were given.
+type groupNode struct {
+	names []string
+	expr  node
+}
+
+// parseGroups parses a full gator tag, which may hold expressions for
+// several groups.  Errors are returned as a *TagError without the
+// Field set.
+func parseGroups(tag string) ([]groupNode, error) {
+	l := &tagLexer{src: tag}
+	groups := []groupNode{}
+	for {
+		names, err := l.parseGroupNames()
+		if err != nil {
+			return nil, err
+		}
+		l.skipSpace()
+		g := groupNode{names: names, expr: &andNode{col: l.pos + 1}}
+		if !l.done() && l.peek() != ';' {
+			if g.expr, err = l.parseExpr(); err != nil {
+				return nil, err
+			}
+		}
+		groups = append(groups, g)
+		l.skipSpace()
+		if l.done() {
+			return groups, nil
+		}
+		if l.peek() != ';' {
+			return nil, l.errorf(l.pos, "unexpected %q", l.peek())
+		}
+		l.pos++
+	}
+}
+
+// parseTag parses tag into an AST.  Errors are returned as a *TagError
+// without the Field set.
+func parseTag(tag string) (node, error) {
+	l := &tagLexer{src: tag}
+	l.skipSpace()
+	if l.done() {
+		return &andNode{col: l.pos + 1}, nil
+	}
+	expr, err := l.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	l.skipSpace()
+	if !l.done() {
+		return nil, l.errorf(l.pos, "unexpected %q", l.peek())
+	}
+	return expr, nil
+}
+
+// tagLexer scans a gator tag.  pos is a byte offset into src.
+type tagLexer struct {
+	src string
+	pos int
+}
+
+func (l *tagLexer) done() bool {
+	return l.pos >= len(l.src)
+}
+
+func (l *tagLexer) peek() byte {
+	return l.src[l.pos]
+}
+
+func (l *tagLexer) skipSpace() {
+	for !l.done() && isSpace(l.peek()) {
+		l.pos++
+	}
+}
+
+func (l *tagLexer) errorf(pos int, format string, a ...interface{}) *TagError {
+	return &TagError{
+		Tag: l.src,
+		Col: pos + 1,
+		Msg: fmt.Sprintf(format, a...),
+	}
+}
+
+// text returns the source scanned since start without surrounding
+// whitespace.
+func (l *tagLexer) text(start int) string {
+	return strings.TrimSpace(l.src[start:l.pos])
+}
+
+// peekOr reports whether the next characters are "||".
+func (l *tagLexer) peekOr() bool {
+	return strings.HasPrefix(l.src[l.pos:], "||")
+}
+
+// parseGroupNames scans group names followed by a colon.  If there
+// aren't any the position is left unchanged and nil is returned.
+func (l *tagLexer) parseGroupNames() ([]string, error) {
+	start := l.pos
+	names := []string{}
+	for {
+		l.skipSpace()
+		nameStart := l.pos
+		for !l.done() && isIdentChar(l.peek()) {
+			l.pos++
+		}
+		name := l.src[nameStart:l.pos]
+		l.skipSpace()
+		switch {
+		case name != "" && !l.done() && l.peek() == ':':
+			l.pos++
+			return append(names, name), nil
+		case name != "" && !l.done() && l.peek() == ',':
+			names = append(names, name)
+			l.pos++
+		default:
+			l.pos = start
+			return nil, nil
+		}
+	}
+}
+
+func (l *tagLexer) parseExpr() (node, error) {
+	l.skipSpace()
+	start := l.pos
+	first, err := l.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	if l.skipSpace(); !l.peekOr() {
+		return first, nil
+	}
+	expr := &orNode{col: start + 1, nodes: []node{first}}
+	for l.peekOr() {
+		l.pos += 2
+		alt, err := l.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		expr.nodes = append(expr.nodes, alt)
+		l.skipSpace()
+	}
+	expr.text = l.text(start)
+	return expr, nil
+}
+
+func (l *tagLexer) parseAnd() (*andNode, error) {
+	l.skipSpace()
+	start := l.pos
+	expr := &andNode{col: start + 1}
+	for {
+		term, err := l.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		expr.nodes = append(expr.nodes, term)
+		l.skipSpace()
+		if l.done() || l.peek() != '|' || l.peekOr() {
+			expr.text = l.text(start)
+			return expr, nil
+		}
+		l.pos++
+	}
+}
+
+func (l *tagLexer) parseUnary() (node, error) {
+	l.skipSpace()
+	if l.done() {
+		return nil, l.errorf(l.pos, "expected token")
+	}
+	start := l.pos
+	switch l.peek() {
+	case '!':
+		l.pos++
+		operand, err := l.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{col: start + 1, text: l.text(start), node: operand}, nil
+	case '(':
+		l.pos++
+		expr, err := l.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		l.skipSpace()
+		if l.done() || l.peek() != ')' {
+			return nil, l.errorf(start, "unclosed parenthesis")
+		}
+		l.pos++
+		return expr, nil
+	}
+	return l.parseTerm()
+}
+
+func (l *tagLexer) parseTerm() (*tokenNode, error) {
+	l.skipSpace()
+	if l.done() {
+		return nil, l.errorf(l.pos, "expected token")
+	}
+	start := l.pos
+	for !l.done() && isIdentChar(l.peek()) {
+		l.pos++
+	}
+	if start == l.pos {
+		return nil, l.errorf(l.pos, "unexpected %q", l.peek())
+	}
+	n := &tokenNode{col: start + 1, name: l.src[start:l.pos]}
+	n.text = n.name
+	l.skipSpace()
+	if l.done() || l.peek() != '(' {
+		return n, nil
+	}
+	if err := l.parseArgs(n); err != nil {
+		return nil, err
+	}
+	n.text = l.text(start)
+	return n, nil
+}
+
+// parseArgs scans from an opening parenthesis to its matching closing
+// parenthesis, recording the raw text and the decoded arguments on n.
+func (l *tagLexer) parseArgs(n *tokenNode) error {
+	open := l.pos
+	l.pos++
+	n.paren = true
+
+	depth := 0
+	argStart := l.pos
+	// segStart is the start of the current argument at any depth
+	segStart := l.pos
+	args := []string{}
+	for {
+		if l.done() {
+			return l.errorf(open, "unclosed parenthesis")
+		}
+		c := l.peek()
+		switch {
+		case c == '\\':
+			l.pos += 2
+			if l.pos > len(l.src) {
+				return l.errorf(l.pos-2, "unterminated escape sequence")
+			}
+			continue
+		case c == '"' || c == '\'':
+			// quotes within bare arguments, e.g. matches(^[a-z' ]+$), are
+			// taken literally
+			if strings.TrimSpace(l.src[segStart:l.pos]) != "" {
+				break
+			}
+			if err := l.skipQuoted(); err != nil {
+				return err
+			}
+			continue
+		case c == '(':
+			depth++
+			segStart = l.pos + 1
+		case c == ')' && depth > 0:
+			depth--
+		case c == ',' && depth > 0:
+			segStart = l.pos + 1
+		case c == ')' || (c == ',' && depth == 0):
+			arg, err := l.decodeArg(argStart, l.pos)
+			if err != nil {
+				return err
+			}
+			args = append(args, arg)
+			if c == ')' {
+				raw := l.src[open+1 : l.pos]
+				trimmed := strings.TrimLeft(raw, " \t\n\r")
+				n.rawCol = open + 2 + len(raw) - len(trimmed)
+				n.raw = strings.TrimSpace(raw)
+				if len(args) > 1 || args[0] != "" || n.raw != "" {
+					n.args = args
+				}
+				l.pos++
+				return nil
+			}
+			argStart = l.pos + 1
+			segStart = argStart
+		}
+		l.pos++
+	}
+}
+
+// skipQuoted advances past a quoted string starting at the current
+// position.
+func (l *tagLexer) skipQuoted() error {
+	start := l.pos
+	quote := l.peek()
+	l.pos++
+	for !l.done() {
+		switch l.peek() {
+		case '\\':
+			l.pos += 2
+			continue
+		case quote:
+			l.pos++
+			return nil
+		}
+		l.pos++
+	}
+	return l.errorf(start, "unterminated quote")
+}
+
+// decodeArg returns the argument found in src[start:end].  Quoted
+// arguments are unescaped, bare arguments are trimmed.
+func (l *tagLexer) decodeArg(start, end int) (string, error) {
+	s := l.src[start:end]
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" || (trimmed[0] != '"' && trimmed[0] != '\'') {
+		return trimmed, nil
+	}
+	qStart := start + strings.Index(s, trimmed[:1])
+	qEnd := qStart + 1
+	for qEnd < end && l.src[qEnd] != trimmed[0] {
+		if l.src[qEnd] == '\\' {
+			qEnd++
+		}
+		qEnd++
+	}
+	if strings.TrimSpace(l.src[qEnd+1:end]) != "" {
+		return "", l.errorf(qEnd+1, "unexpected text after quoted argument")
+	}
+	return l.unquote(qStart+1, qEnd)
+}
+
+func (l *tagLexer) unquote(start, end int) (string, error) {
+	b := strings.Builder{}
+	for i := start; i < end; i++ {
+		c := l.src[i]
+		if c != '\\' {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch e := l.src[i]; e {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '\\', '\'', '"', '|', ',', '(', ')':
+			b.WriteByte(e)
+		default:
+			return "", l.errorf(i-1, "invalid escape sequence \\%c", e)
+		}
+	}
+	return b.String(), nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,121 @@
+package gator_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/ShaleApps/gator"
+)
+
+type tagTest struct {
+	Tag   string
+	Value interface{}
+}
+
+var (
+	validTags = []*tagTest{
+		{`each( gt(18) | lt(35) )`, []int{19, 34}},
+		{`each(in(a, b) | len(1))`, []string{"a", "b"}},
+		{`in("a,b", 'c|d')`, "a,b"},
+		{`in("a,b", 'c|d')`, "c|d"},
+		{`in( a , b )`, "b"},
+		{`in('it\'s', "say \"hi\"")`, `say "hi"`},
+		{`notin(Superman,Batman,The Flash)`, "Green Lantern"},
+		{`matches(^\d{5}(?:[-\s]\d{4})?$)`, "12345-6789"},
+		{`matches(^\(\d{3}\)$)`, "(123)"},
+		{`matches('^a|b$')`, "b"},
+		{`eq("a b")`, "a b"},
+		{` nonzero  |  minlen( 2 ) `, "ab"},
+		{``, ""},
+		{`ip || hostname`, "192.168.0.1"},
+		{`ip || hostname`, "example.com"},
+		{`!matches(^\d+$)`, "abc"},
+		{`not(in(a, b) || len(3))`, "cd"},
+		{`minlen(2) | (eq(ab) || eq(cd)) | !eq(cd)`, "ab"},
+		{`!eq(a) | !eq(b) || eq(b)`, "b"},
+		{`each(!eq(0) || eq(1))`, []int{1, 2}},
+		{`matches(^[a-zA-Z' ]+$)`, "Mary O'Neil"},
+		{`eq(it's)`, "it's"},
+		{`in(a"b, c)`, `a"b`},
+		{`each(matches(^[a' ]+$) | !eq("a"))`, []string{"a'a", " "}},
+	}
+
+	invalidTags = []*tagTest{
+		{`each( gt(18) | lt(35) )`, []int{19, 36}},
+		{`in("a,b", 'c|d')`, "a"},
+		{`in("a,b", 'c|d')`, "c"},
+		{`matches('^a|b$')`, "c"},
+		{`ip || hostname`, "not a host"},
+		{`!matches(^\d+$)`, "123"},
+		{`not(in(a, b) || len(3))`, "abc"},
+		{`minlen(2) | (eq(ab) || eq(cd)) | !eq(cd)`, "cd"},
+		{`each(!eq(0) || eq(1))`, []int{1, 0}},
+		{`matches(^[a-zA-Z' ]+$)`, "O'Neil 3rd"},
+		{`eq(it's)`, "its"},
+	}
+)
+
+func TestTags(t *testing.T) {
+	for _, tt := range validTags {
+		g := gator.New().Add(tagStruct(tt))
+		if err := g.Validate(); err != nil {
+			t.Errorf("%q should be valid for %v: %s", tt.Tag, tt.Value, err)
+		}
+	}
+	for _, tt := range invalidTags {
+		g := gator.New().Add(tagStruct(tt))
+		if err := g.Validate(); err == nil {
+			t.Errorf("%q should be invalid for %v", tt.Tag, tt.Value)
+		}
+	}
+}
+
+func tagStruct(tt *tagTest) gator.Validator {
+	return gator.NewQueryStr(struct{ Field interface{} }{tt.Value}, "Field="+url.QueryEscape(tt.Tag))
+}
+
+func TestMalformedTags(t *testing.T) {
+	tests := []struct {
+		tag string
+		col int
+	}{
+		{`minlen(5`, 7},
+		{`minlen(5))`, 10},
+		{`in("a,b)`, 4},
+		{`in("a" b)`, 7},
+		{`email |`, 8},
+		{`email ||`, 9},
+		{`email ||| url`, 9},
+		{`(email | url`, 1},
+		{`!`, 2},
+		{`not(email | )`, 12},
+		{`@email`, 1},
+		{`each(gt(1) | lt(2)`, 5},
+		{`each(gt(1) | lt(2)))`, 20},
+		{`each( gt(1) | @ )`, 15},
+		{`in('a\qb')`, 6},
+		{`create:email update:url`, 14},
+		{`create:email; update:@`, 22},
+	}
+	for _, tt := range tests {
+		err := gator.NewQueryStr(&struct{ Name string }{}, "Name="+url.QueryEscape(tt.tag)).Validate()
+		var tErr *gator.TagError
+		if !errors.As(err, &tErr) {
+			t.Errorf("%q should produce a TagError, got %v", tt.tag, err)
+			continue
+		}
+		if tErr.Field != "Name" || tErr.Col != tt.col {
+			t.Errorf("%q expected error for Name at column %d, got %s", tt.tag, tt.col, tErr)
+		}
+	}
+
+	type badStruct struct {
+		Ages []int `gator:"each(gt(18) | lt(35)"`
+	}
+	err := gator.NewStruct(badStruct{}).Validate()
+	var tErr *gator.TagError
+	if !errors.As(err, &tErr) || tErr.Field != "Ages" {
+		t.Errorf("expected TagError for Ages, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package gator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// fieldSet is a tree of selected struct fields keyed by Go field name.
+// A field that maps to a nil fieldSet is selected along with every
+// field nested in it.
+type fieldSet map[string]fieldSet
+
+// add selects the field with the dotted path.  Indexes are ignored.
+func (s fieldSet) add(path string) {
+	names := []string{}
+	for _, name := range strings.Split(path, ".") {
+		if i := strings.IndexByte(name, '['); i >= 0 {
+			name = name[:i]
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	for i, name := range names {
+		child, ok := s[name]
+		switch {
+		case ok && child == nil:
+			// already selected
+			return
+		case i == len(names)-1:
+			s[name] = nil
+			return
+		case !ok:
+			child = fieldSet{}
+			s[name] = child
+		}
+		s = child
+	}
+}
+
+// NewMergePatch is like NewStruct but only validates the fields present
+// in patch, a JSON merge patch (RFC 7386) that has been applied to src.
+// Keys are matched to fields the way encoding/json matches them.  Nested
+// objects select the fields of nested structs, other values select the
+// whole field.  If patch isn't a JSON object an error will be returned
+// from the Validate method.
+func NewMergePatch(src interface{}, patch []byte, options ...func(*Gator)) *Gator {
+	doc := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &doc); err != nil {
+		g := New(options...)
+		g.Add(errValidator{err: fmt.Errorf("gator: couldn't parse merge patch - %s", err)})
+		return g
+	}
+	fields := fieldSet{}
+	if t := reflect.TypeOf(src); t != nil {
+		fields = patchFields(indirectType(t), doc)
+	}
+	return NewStruct(src, append(options, func(g *Gator) {
+		g.fields = fields
+	})...)
+}
+
+// patchFields returns the fields of t, a struct type, selected by the
+// keys of patch.
+func patchFields(t reflect.Type, patch map[string]interface{}) fieldSet {
+	fields := fieldSet{}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for key, value := range patch {
+		f, ok := jsonField(t, key)
+		if !ok {
+			continue
+		}
+		var child fieldSet
+		if obj, ok := value.(map[string]interface{}); ok {
+			if ft := indirectType(f.Type); ft.Kind() == reflect.Struct {
+				child = patchFields(ft, obj)
+			}
+		}
+		fields[f.Name] = child
+	}
+	return fields
+}
+
+// jsonField returns the field of t, a struct type, that encoding/json
+// would decode key into.  Exact matches are preferred over case
+// insensitive ones.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fold *reflect.StructField
+	for _, f := range reflect.VisibleFields(t) {
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if name == "" {
+			if f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct {
+				// the fields of embedded structs are promoted
+				continue
+			}
+			name = f.Name
+		}
+		if name == key {
+			return f, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			f := f
+			fold = &f
+		}
+	}
+	if fold != nil {
+		return *fold, true
+	}
+	return reflect.StructField{}, false
+}
+
+// indirectType returns the type t points to if it is a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
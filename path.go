@@ -0,0 +1,111 @@
+package gator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// A Path locates a value from the root struct being validated, e.g. the
+// Zip field of the Origin of the fourth element of Shipments.
+type Path []PathElem
+
+// A PathElem is a step in a Path.  It is either a struct field or an
+// index into a slice, array or map.
+type PathElem struct {
+	// Field is the name of a struct field.  It is empty for indexes.
+	Field string
+	// Index is the slice or array index or map key.  It is only used if
+	// Field is empty.
+	Index interface{}
+}
+
+// String returns the path in dotted and bracketed form, e.g.
+// "Shipments[3].Origin.Zip".
+func (p Path) String() string {
+	b := strings.Builder{}
+	for _, e := range p {
+		if e.Field == "" {
+			fmt.Fprintf(&b, "[%v]", e.Index)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(e.Field)
+	}
+	return b.String()
+}
+
+// JSONPointer returns the path as a JSON Pointer (RFC 6901), e.g.
+// "/Shipments/3/Origin/Zip".
+func (p Path) JSONPointer() string {
+	b := strings.Builder{}
+	for _, e := range p {
+		s := e.Field
+		if s == "" {
+			s = fmt.Sprint(e.Index)
+		}
+		b.WriteByte('/')
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(s))
+	}
+	return b.String()
+}
+
+// field returns a copy of p extended by the named field.
+func (p Path) field(name string) Path {
+	return append(p[:len(p):len(p)], PathElem{Field: name})
+}
+
+// index returns a copy of p extended by an index or map key.
+func (p Path) index(i interface{}) Path {
+	return append(p[:len(p):len(p)], PathElem{Index: i})
+}
+
+// A FieldNamer names struct fields in errors.  Plans are cached per
+// FieldNamer, so custom FieldNamers should be created once and reused.
+type FieldNamer struct {
+	name func(f reflect.StructField) string
+}
+
+// NewFieldNamer returns a FieldNamer that names fields using f.  If f
+// returns an empty string the Go name of the field is used.  The fields
+// of embedded structs are reported as the embedding struct's own unless
+// f names the embedded struct.
+func NewFieldNamer(f func(f reflect.StructField) string) *FieldNamer {
+	return &FieldNamer{name: f}
+}
+
+var (
+	// GoNames names fields by their Go names.  It is the default.
+	GoNames = NewFieldNamer(func(f reflect.StructField) string {
+		if f.Anonymous {
+			return ""
+		}
+		return f.Name
+	})
+	// JSONNames names fields by the names in their json tags.
+	JSONNames = NewFieldNamer(tagName("json"))
+	// FormNames names fields by the names in their form tags.
+	FormNames = NewFieldNamer(tagName("form"))
+)
+
+// fieldName returns the name of f.
+func (n *FieldNamer) fieldName(f reflect.StructField) string {
+	if name := n.name(f); name != "" {
+		return name
+	}
+	return f.Name
+}
+
+// tagName returns a func that names fields by the first comma separated
+// value of the struct tag with the given key.
+func tagName(key string) func(f reflect.StructField) string {
+	return func(f reflect.StructField) string {
+		name := strings.Split(f.Tag.Get(key), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	}
+}
@@ -0,0 +1,328 @@
+package gator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A Plan is the compiled form of a struct type's gator tags.  Compile
+// parses the tags of a type once and caches the result, so a Plan can
+// validate any number of values of that type without parsing tags
+// again.  Plans are safe for concurrent use.
+type Plan struct {
+	typ    reflect.Type
+	sp     *structPlan
+	cfg    compileConfig
+	mode   Mode
+	groups []string
+	fields fieldSet
+	locale string
+	lookup lookupConfig
+}
+
+// Compile returns a Plan for the type of src, which must be a struct
+// or a pointer to a struct.  The pointer may be nil.  options are
+// applied as they are by New.  If any of the tags reachable from the
+// type can't be compiled a TagErrors is returned.
+func Compile(src interface{}, options ...func(*Gator)) (*Plan, error) {
+	t := reflect.TypeOf(src)
+	if t != nil && isStructPtr(t) {
+		t = t.Elem()
+	}
+	if t == nil || !isStruct(t) {
+		return nil, errors.New("gator: src must be a struct or a pointer to a struct")
+	}
+	p, errs := New(options...).compile(t)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return p, nil
+}
+
+// MustCompile is like Compile but panics if the Plan can't be
+// compiled.  It simplifies the initialization of global Plans.
+func MustCompile(src interface{}, options ...func(*Gator)) *Plan {
+	p, err := Compile(src, options...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Validate validates v, which must be a value of, or non-nil pointer
+// to, the Plan's type.
+func (p *Plan) Validate(v interface{}) error {
+	return p.validate(context.Background(), p.locale, v)
+}
+
+// ValidateContext is like Validate but passes ctx to the ContextFuncs of
+// tokens.  If ctx is done before validation finishes a *ContextError is
+// returned.
+func (p *Plan) ValidateContext(ctx context.Context, v interface{}) error {
+	return p.validate(ctx, p.locale, v)
+}
+
+// ValidateLocale is like Validate but reports messages in locale, e.g.
+// "es".  See Registry.AddCatalog.
+func (p *Plan) ValidateLocale(v interface{}, locale string) error {
+	return p.validate(context.Background(), locale, v)
+}
+
+// ValidateChange validates new like Validate and checks tokens such as
+// immutable and transition against old, the previous value.  Both must
+// be values of, or non-nil pointers to, the Plan's type.
+func (p *Plan) ValidateChange(old, new interface{}) error {
+	return p.validateChange(context.Background(), p.locale, old, new)
+}
+
+func (p *Plan) validate(ctx context.Context, locale string, v interface{}) error {
+	rv, err := p.value(v)
+	if err != nil {
+		return err
+	}
+	return p.run(ctx, locale, rv, reflect.Value{})
+}
+
+func (p *Plan) validateChange(ctx context.Context, locale string, old, new interface{}) error {
+	ov, err := p.value(old)
+	if err != nil {
+		return err
+	}
+	nv, err := p.value(new)
+	if err != nil {
+		return err
+	}
+	return p.run(ctx, locale, nv, reflect.Indirect(ov))
+}
+
+// run validates v, a value of, or pointer to, the Plan's type and the
+// change from old, a value of the Plan's type, if it is valid.
+func (p *Plan) run(ctx context.Context, locale string, v, old reflect.Value) error {
+	r := newPlanRun(ctx, p, locale)
+	if r.canceled() {
+		return r.err()
+	}
+	if v.Kind() == reflect.Ptr {
+		r.enter(v)
+		v = v.Elem()
+	}
+	r.root = v
+	r.walk(nil, v, old, p.sp, p.fields)
+	r.resolve()
+	return r.err()
+}
+
+// value returns v as a reflect.Value if it can be validated by p.
+func (p *Plan) value(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.IsValid() && rv.Type() == p.typ:
+	case rv.IsValid() && rv.Kind() == reflect.Ptr && rv.Type().Elem() == p.typ:
+		if rv.IsNil() {
+			return rv, errors.New("gator: src is a nil pointer")
+		}
+	default:
+		return rv, fmt.Errorf("gator: Plan for %s can't validate %T", p.typ, v)
+	}
+	return rv, nil
+}
+
+// compile returns a Plan for t, a struct type, using the Gator's
+// options along with the TagErrors of t and the struct types reachable
+// from it.
+func (g *Gator) compile(t reflect.Type) (*Plan, TagErrors) {
+	cfg := g.compileConfig()
+	sp := structPlanFor(cfg, t)
+	return g.plan(t, sp, cfg), sp.tagErrors()
+}
+
+// plan returns a Plan that validates t using sp and the Gator's Mode,
+// groups, selected fields, locale and Resolver.
+func (g *Gator) plan(t reflect.Type, sp *structPlan, cfg compileConfig) *Plan {
+	groups := g.groups
+	if len(groups) == 0 {
+		groups = []string{DefaultGroup}
+	}
+	return &Plan{typ: t, sp: sp, cfg: cfg, mode: g.mode, groups: groups, fields: g.fields, locale: g.locale, lookup: g.lookup}
+}
+
+// compileConfig holds the options that change how a type is compiled.
+// It is part of the key plans are cached under.
+type compileConfig struct {
+	reg        *Registry
+	tagKey     string
+	strict     bool
+	unexported bool
+	namer      *FieldNamer
+}
+
+func (g *Gator) compileConfig() compileConfig {
+	return compileConfig{
+		reg:        g.reg,
+		tagKey:     g.tagKey,
+		strict:     g.strict,
+		unexported: g.unexported,
+		namer:      g.namer,
+	}
+}
+
+// structPlan holds the compiled fields of a struct type.
+type structPlan struct {
+	typ    reflect.Type
+	fields []*fieldPlan
+	// errs holds the TagErrors found in the type's own tags.
+	errs TagErrors
+	// children holds the plans of the struct types that can be reached
+	// through the type's fields.
+	children []*structPlan
+	// structFuncs holds the struct level validations of the type.
+	structFuncs []StructFunc
+	// fieldsOnly is set for the plans of NewQueryStr, which only check
+	// the rules of the query string.
+	fieldsOnly bool
+}
+
+// fieldPlan holds the compiled rules of a struct field.
+type fieldPlan struct {
+	index int
+	field reflect.StructField
+	// name is the Go name of the field, pathName is its name in errors.
+	name      string
+	pathName  string
+	anonymous bool
+	// promoted is set for embedded fields that the FieldNamer doesn't
+	// name, whose fields are reported as the embedding struct's own.
+	promoted bool
+	rules    []rule
+	// walk is set if the field may hold structs that need to be
+	// validated.
+	walk bool
+	// plan is the plan of the struct type held by the field, if any.
+	plan *structPlan
+}
+
+// tagErrors returns the TagErrors of sp and every plan reachable from
+// it.  Errors of reachable types have fields named after the type,
+// e.g. "Address.Zip".
+func (sp *structPlan) tagErrors() TagErrors {
+	errs := TagErrors{}
+	for i, p := range sp.reachable() {
+		for _, tErr := range p.errs {
+			e := *tErr
+			if i > 0 {
+				e.Field = p.typ.Name() + "." + e.Field
+			}
+			errs = append(errs, &e)
+		}
+	}
+	return errs
+}
+
+// reachable returns sp followed by every plan reachable from it.
+func (sp *structPlan) reachable() []*structPlan {
+	plans := []*structPlan{sp}
+	seen := map[*structPlan]bool{sp: true}
+	var visit func(p *structPlan)
+	visit = func(p *structPlan) {
+		for _, child := range p.children {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			plans = append(plans, child)
+			visit(child)
+		}
+	}
+	visit(sp)
+	return plans
+}
+
+type planKey struct {
+	cfg compileConfig
+	typ reflect.Type
+}
+
+// plans caches structPlans by planKey.
+var plans sync.Map
+
+// resetPlans removes the plans compiled with r from the cache.  It is
+// called when tokens are registered because cached plans hold the
+// Funcs of old tokens.
+func resetPlans(r *Registry) {
+	plans.Range(func(k, v interface{}) bool {
+		if k.(planKey).cfg.reg == r {
+			plans.Delete(k)
+		}
+		return true
+	})
+}
+
+// structPlanFor returns the cached plan for t, compiling t and the
+// struct types reachable from it if needed.
+func structPlanFor(cfg compileConfig, t reflect.Type) *structPlan {
+	if sp, ok := plans.Load(planKey{cfg: cfg, typ: t}); ok {
+		return sp.(*structPlan)
+	}
+	gen := cfg.reg.generation()
+	pending := map[reflect.Type]*structPlan{}
+	sp := compileStruct(cfg, t, pending)
+	// plans compiled while tokens were registered aren't cached
+	cfg.reg.cachePlans(gen, func() {
+		for typ, p := range pending {
+			plans.LoadOrStore(planKey{cfg: cfg, typ: typ}, p)
+		}
+	})
+	return sp
+}
+
+// compileStruct compiles t, a struct type, and the struct types
+// reachable from its fields.  Plans that are being compiled are kept in
+// pending so that recursive types terminate.
+func compileStruct(cfg compileConfig, t reflect.Type, pending map[reflect.Type]*structPlan) *structPlan {
+	if sp, ok := pending[t]; ok {
+		return sp
+	}
+	if sp, ok := plans.Load(planKey{cfg: cfg, typ: t}); ok {
+		return sp.(*structPlan)
+	}
+	sp := &structPlan{typ: t, structFuncs: cfg.reg.structValidations(t)}
+	pending[t] = sp
+
+	c := &compiler{reg: cfg.reg, strict: cfg.strict, typ: t}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		exported := field.PkgPath == ""
+		if !exported && !field.Anonymous && !cfg.unexported {
+			continue
+		}
+		fp := &fieldPlan{
+			index:     i,
+			field:     field,
+			name:      field.Name,
+			pathName:  cfg.namer.fieldName(field),
+			anonymous: field.Anonymous,
+			promoted:  field.Anonymous && cfg.namer.name(field) == "",
+			walk:      canContainStruct(field.Type),
+		}
+		if exported || cfg.unexported {
+			fp.rules = c.rules(field.Name, field.Tag.Get(cfg.tagKey), field.Tag.Get(cfg.tagKey+"_msg"))
+		}
+		if st, ok := structType(field.Type); ok {
+			fp.plan = compileStruct(cfg, st, pending)
+			sp.children = append(sp.children, fp.plan)
+		}
+		sp.fields = append(sp.fields, fp)
+	}
+	sp.errs = c.errs
+	return sp
+}
+
+// structType returns the struct type held by values of type t through
+// pointers, slices, arrays and maps.
+func structType(t reflect.Type) (reflect.Type, bool) {
+	t = elemType(t)
+	return t, t.Kind() == reflect.Struct
+}
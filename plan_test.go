@@ -0,0 +1,134 @@
+package gator_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ShaleApps/gator"
+)
+
+type node struct {
+	Name     string `gator:"nonzero"`
+	Children []*node
+	Parent   *node
+	Value    interface{}
+}
+
+func TestPlan(t *testing.T) {
+	p, err := gator.Compile((*node)(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := &node{Name: "root"}
+	n.Children = []*node{{Name: "child", Parent: n}}
+	if err := p.Validate(n); err != nil {
+		t.Errorf("node should be valid: %s", err)
+	}
+	if err := p.Validate(*n); err != nil {
+		t.Errorf("node should be valid: %s", err)
+	}
+	n.Children[0].Name = ""
+	if err := p.Validate(n); err == nil {
+		t.Error("node should be invalid after change")
+	}
+	n.Children[0].Name = "child"
+	n.Value = &address{"1"}
+	if err := p.Validate(n); err == nil {
+		t.Error("struct held in interface should be validated")
+	}
+
+	for _, v := range []interface{}{nil, (*node)(nil), testStruct1{}} {
+		if err := p.Validate(v); err == nil {
+			t.Errorf("%#v should produce an error", v)
+		}
+	}
+
+	g := gator.NewStruct(n)
+	if err := g.Validate(); err == nil {
+		t.Error("node should be invalid")
+	}
+	n.Value = nil
+	if err := g.Validate(); err != nil {
+		t.Errorf("Gator should be reusable after change: %s", err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	type inner struct {
+		Zip string `gator:"len(5"`
+	}
+	type outer struct {
+		Name  string `gator:"nonzero"`
+		Inner []inner
+	}
+	_, err := gator.Compile(outer{})
+	var tErr *gator.TagError
+	if !errors.As(err, &tErr) || tErr.Field != "inner.Zip" {
+		t.Errorf("expected TagError for inner.Zip, got %v", err)
+	}
+	if _, err := gator.Compile(1); err == nil {
+		t.Error("compiling an int should produce an error")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustCompile should panic")
+		}
+	}()
+	gator.MustCompile(outer{})
+}
+
+func TestPlanConcurrency(t *testing.T) {
+	p := gator.MustCompile(testStruct3{}, gator.WithMode(gator.AllErrors))
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := p.Validate(&testStruct3{"http://www.google.com", "logan12345"}); err != nil {
+					t.Error(err)
+				}
+				if err := gator.NewStruct(&testStruct3{"http://google", "log1"}).Validate(); err == nil {
+					t.Error("struct should be invalid")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type racer struct {
+	N int `gator:"racy"`
+}
+
+func TestRegisterConcurrency(t *testing.T) {
+	r := gator.NewRegistry()
+	for i := 0; i < 200; i++ {
+		want := i
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.Register("racy", func(string) gator.Func {
+				return func(name string, v interface{}) error {
+					if v != want {
+						return fmt.Errorf("%s must be %d.", name, want)
+					}
+					return nil
+				}
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			gator.NewStruct(&racer{}, gator.WithRegistry(r)).Validate()
+		}()
+		wg.Wait()
+		if err := gator.NewStruct(&racer{N: i}, gator.WithRegistry(r)).Validate(); err != nil {
+			t.Fatalf("expected the plan to use the latest token, got %s", err)
+		}
+	}
+}
@@ -0,0 +1,579 @@
+package gator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// A Registry holds the tokens that can be used in gator tags.  Gators
+// use the default Registry unless they are created WithRegistry.
+// Registries are safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	tokens map[string]tokenDef
+	// messages holds the messages of tokens and catalogs holds the
+	// messages of other locales keyed by locale.
+	messages map[string]messageForms
+	catalogs map[string]*Catalog
+	// structFuncs holds the struct level validations of struct types.
+	structFuncs map[reflect.Type][]StructFunc
+	// gen is incremented whenever a token is registered so that plans
+	// compiled with old tokens aren't cached.
+	gen uint64
+}
+
+var (
+	// builtinRegistry holds the built-in tokens.
+	builtinRegistry = &Registry{
+		tokens:      map[string]tokenDef{},
+		messages:    map[string]messageForms{},
+		catalogs:    map[string]*Catalog{},
+		structFuncs: map[reflect.Type][]StructFunc{},
+	}
+	// defaultRegistry is used by Gators that aren't created
+	// WithRegistry.
+	defaultRegistry *Registry
+)
+
+// NewRegistry returns a Registry holding the built-in tokens.
+func NewRegistry() *Registry {
+	return builtinRegistry.Clone()
+}
+
+// DefaultRegistry returns the Registry used by Gators that aren't
+// created WithRegistry.  It holds the built-in tokens and those added
+// with RegisterStructTagToken.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Clone returns a copy of r.  Tokens registered with the copy don't
+// affect r and vice versa.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := &Registry{
+		tokens:      make(map[string]tokenDef, len(r.tokens)),
+		messages:    make(map[string]messageForms, len(r.messages)),
+		catalogs:    make(map[string]*Catalog, len(r.catalogs)),
+		structFuncs: make(map[reflect.Type][]StructFunc, len(r.structFuncs)),
+	}
+	for token, def := range r.tokens {
+		c.tokens[token] = def
+	}
+	for token, msg := range r.messages {
+		c.messages[token] = msg
+	}
+	for locale, catalog := range r.catalogs {
+		c.catalogs[locale] = catalog
+	}
+	for t, funcs := range r.structFuncs {
+		c.structFuncs[t] = funcs
+	}
+	return c
+}
+
+// Register registers a custom token with r.  convFunc receives the
+// token's argument: a single argument is unquoted and unescaped,
+// otherwise the raw text between the parentheses is passed through.
+func (r *Registry) Register(token string, convFunc func(string) Func) {
+	r.register(token, anyArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return funcCheck(convFunc(n.arg())), nil
+	})
+}
+
+// RegisterContext is like Register but registers a token whose
+// ContextFunc receives the context passed to ValidateContext.
+func (r *Registry) RegisterContext(token string, convFunc func(string) ContextFunc) {
+	r.register(token, anyArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return contextFuncCheck(convFunc(n.arg())), nil
+	})
+}
+
+// RegisterFieldFunc is like Register but registers a token whose
+// FieldFunc receives a FieldContext describing the field, its struct and
+// the token's arguments.  Use AdaptFunc and AdaptContextFunc to register
+// existing Funcs and ContextFuncs this way.
+func (r *Registry) RegisterFieldFunc(token string, f FieldFunc) {
+	r.register(token, anyArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return fieldFuncCheck(n.name, n.args, f), nil
+	})
+}
+
+// RegisterStructValidation registers f as a struct level validation of
+// the type of src, which must be a struct or a pointer to a struct.  f
+// is called with every value of the type found by NewStruct after the
+// value's fields are validated.  It panics if src isn't a struct.
+func (r *Registry) RegisterStructValidation(src interface{}, f StructFunc) {
+	t := reflect.TypeOf(src)
+	if t != nil && isStructPtr(t) {
+		t = t.Elem()
+	}
+	if t == nil || !isStruct(t) {
+		panic("gator: RegisterStructValidation requires a struct or a pointer to a struct")
+	}
+	r.update(func() {
+		funcs := r.structFuncs[t]
+		r.structFuncs[t] = append(funcs[:len(funcs):len(funcs)], f)
+	})
+}
+
+// structValidations returns the struct level validations of t.
+func (r *Registry) structValidations(t reflect.Type) []StructFunc {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.structFuncs[t]
+}
+
+func (r *Registry) register(token string, a arity, f tokenFunc) {
+	r.define(token, tokenDef{arity: a, f: f})
+}
+
+func (r *Registry) define(token string, def tokenDef) {
+	r.update(func() {
+		r.tokens[token] = def
+	})
+}
+
+// update applies f, a change to r, and removes the plans compiled with
+// r from the cache.  Plans being compiled while r changes aren't
+// cached, see cachePlans.
+func (r *Registry) update(f func()) {
+	r.mu.Lock()
+	f()
+	atomic.AddUint64(&r.gen, 1)
+	r.mu.Unlock()
+	resetPlans(r)
+}
+
+// cachePlans calls store, which caches plans compiled with r, unless r
+// changed since generation returned gen.  Changes wait for store to
+// return, so the plans it caches are either current or removed by the
+// change's resetPlans.
+func (r *Registry) cachePlans(gen uint64, store func()) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.generation() == gen {
+		store()
+	}
+}
+
+// SetMessage sets the message reported when a field fails token.  text
+// is a text/template executed with a MessageData, e.g.
+// "{{.Field}} must be at least {{.Arg}} characters".  Messages replace
+// the generic errors of Funcs that don't describe why a value failed.
+// An error is returned if text can't be parsed.
+func (r *Registry) SetMessage(token, text string) error {
+	return r.SetPluralMessage(token, map[string]string{"other": text})
+}
+
+// SetPluralMessage is like SetMessage but sets plural forms keyed by
+// CLDR plural category.  See Catalog.SetPlural.
+func (r *Registry) SetPluralMessage(token string, forms map[string]string) error {
+	m, err := parseForms(token, forms)
+	if err != nil {
+		return err
+	}
+	r.update(func() {
+		r.messages[token] = m
+	})
+	return nil
+}
+
+// AddCatalog adds the messages of c for its locale.  They are used
+// when validating in that locale, or a more specific one such as
+// "es-MX" for "es".  The Registry's own messages are used for tokens
+// missing from c.  Changes made to c afterwards have no effect.
+func (r *Registry) AddCatalog(c *Catalog) {
+	cp := NewCatalog(c.locale)
+	for token, m := range c.messages {
+		cp.messages[token] = m
+	}
+	r.mu.Lock()
+	if existing, ok := r.catalogs[cp.locale]; ok {
+		for token, m := range existing.messages {
+			if _, ok := cp.messages[token]; !ok {
+				cp.messages[token] = m
+			}
+		}
+	}
+	r.catalogs[cp.locale] = cp
+	r.mu.Unlock()
+}
+
+func (r *Registry) message(token string) messageForms {
+	r.mu.RLock()
+	msg := r.messages[token]
+	r.mu.RUnlock()
+	return msg
+}
+
+// Message returns the message reported when a field fails data.Token,
+// rendered in locale using r's Catalogs or in English if they have no
+// message for the token.  ok is false if the token has no message.
+func (r *Registry) Message(locale string, data MessageData) (msg string, ok bool) {
+	forms := r.message(data.Token)
+	if m, found := r.localized(locale, data.Token); found && locale != "" {
+		forms = m
+	}
+	if forms == nil {
+		return "", false
+	}
+	msg, err := forms.render(locale, data)
+	return msg, err == nil
+}
+
+// localized returns the message of token in the Catalog for locale or
+// its base language.
+func (r *Registry) localized(locale, token string) (messageForms, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, l := range []string{normalizeLocale(locale), baseLocale(locale)} {
+		if c, ok := r.catalogs[l]; ok {
+			if m, ok := c.messages[token]; ok {
+				return m, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) lookup(token string) (tokenDef, bool) {
+	r.mu.RLock()
+	def, ok := r.tokens[token]
+	r.mu.RUnlock()
+	return def, ok
+}
+
+func (r *Registry) generation() uint64 {
+	return atomic.LoadUint64(&r.gen)
+}
+
+// RegisterStructTagToken registers custom tokens for gator struct tags
+// with the default Registry.  See Registry.Register.
+func RegisterStructTagToken(token string, convFunc func(string) Func) {
+	defaultRegistry.Register(token, convFunc)
+}
+
+// RegisterStructTagFieldFunc registers custom tokens whose FieldFuncs
+// receive a FieldContext with the default Registry.  See
+// Registry.RegisterFieldFunc.
+func RegisterStructTagFieldFunc(token string, f FieldFunc) {
+	defaultRegistry.RegisterFieldFunc(token, f)
+}
+
+// RegisterStructValidation registers a struct level validation of the
+// type of src with the default Registry.  See
+// Registry.RegisterStructValidation.
+func RegisterStructValidation(src interface{}, f StructFunc) {
+	defaultRegistry.RegisterStructValidation(src, f)
+}
+
+// RegisterStructTagTokenContext registers custom tokens whose
+// ContextFuncs receive the context passed to ValidateContext with the
+// default Registry.  See Registry.RegisterContext.
+func RegisterStructTagTokenContext(token string, convFunc func(string) ContextFunc) {
+	defaultRegistry.RegisterContext(token, convFunc)
+}
+
+func init() {
+	r := builtinRegistry
+	r.register("nonzero", noArgs, simpleToken(Nonzero))
+	r.register("omitempty", noArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return func(fc *fieldContext) error {
+			if isZero(fc.value) {
+				return errSkip
+			}
+			return nil
+		}, nil
+	})
+	r.register("omitnil", noArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return func(fc *fieldContext) error {
+			if isNil(fc.value) {
+				return errSkip
+			}
+			return nil
+		}, nil
+	})
+	r.register("notnil", noArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return func(fc *fieldContext) error {
+			if isNil(fc.value) {
+				return formatError(fc.name)
+			}
+			return nil
+		}, nil
+	})
+	r.register("eq", oneArg, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return funcCheck(Eq(n.arg())), nil
+	})
+	r.register("email", noArgs, simpleToken(Email))
+	r.register("hexcolor", noArgs, simpleToken(HexColor))
+	r.register("url", noArgs, simpleToken(URL))
+	r.register("ip", noArgs, simpleToken(IP))
+	r.register("alpha", noArgs, simpleToken(Alpha))
+	r.register("num", noArgs, simpleToken(Num))
+	r.register("alphanum", noArgs, simpleToken(AlphaNum))
+	r.register("matches", oneArg, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		re, err := regexp.Compile(n.arg())
+		if err != nil {
+			return nil, err
+		}
+		return funcCheck(matchRegexp(re)), nil
+	})
+	r.register("lat", noArgs, simpleToken(Lat))
+	r.register("lon", noArgs, simpleToken(Lon))
+	r.register("gt", oneArg, floatToken(Gt))
+	r.register("gte", oneArg, floatToken(Gte))
+	r.register("lt", oneArg, floatToken(Lt))
+	r.register("lte", oneArg, floatToken(Lte))
+	r.register("in", listArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return funcCheck(In(argList(n))), nil
+	})
+	r.register("notin", listArgs, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return funcCheck(NotIn(argList(n))), nil
+	})
+	r.register("len", oneArg, intToken(Len))
+	r.register("minlen", oneArg, intToken(MinLen))
+	r.register("maxlen", oneArg, intToken(MaxLen))
+	r.register("each", oneArg, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		expr, err := n.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return eachCheck(c.compile(expr)), nil
+	})
+	r.register("not", oneArg, func(c *compiler, n *tokenNode) (checkFunc, error) {
+		expr, err := n.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return notCheck(rulesCheck(c.compile(expr))), nil
+	})
+	r.register("hostname", noArgs, simpleToken(Hostname))
+	r.register("immutable", noArgs, changeToken(func(old, new interface{}) bool {
+		return compareValues(eq, old, new)
+	}))
+	r.register("immutable_once_set", noArgs, changeToken(func(old, new interface{}) bool {
+		return isZero(old) || compareValues(eq, old, new)
+	}))
+	r.register("transition", listArgs, transitionToken)
+	r.register("eqfield", oneArg, fieldToken(eq, false))
+	r.register("nefield", oneArg, fieldToken(eq, true))
+	r.register("gtfield", oneArg, fieldToken(gt, false))
+	r.register("gtefield", oneArg, fieldToken(gte, false))
+	r.register("ltfield", oneArg, fieldToken(lt, false))
+	r.register("ltefield", oneArg, fieldToken(lte, false))
+	r.register("required_if", listArgs, requiredIfToken(false, false))
+	r.register("required_unless", listArgs, requiredIfToken(true, false))
+	r.register("excluded_if", listArgs, requiredIfToken(false, true))
+	r.register("required_with", listArgs, requiredWithToken(false))
+	r.register("required_without", listArgs, requiredWithToken(true))
+	r.define("exists", tokenDef{arity: oneArg, f: lookupToken(true), lookup: true})
+	r.define("unique", tokenDef{arity: oneArg, f: lookupToken(false), lookup: true})
+	fsys, err := fs.Sub(builtinCatalogs, "catalogs")
+	if err != nil {
+		panic(err)
+	}
+	catalogs, err := LoadCatalogs(fsys)
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range catalogs {
+		if c.locale != "en" {
+			r.AddCatalog(c)
+			continue
+		}
+		for token, m := range c.messages {
+			r.messages[token] = m
+		}
+	}
+	defaultRegistry = builtinRegistry.Clone()
+}
+
+func simpleToken(fn func() Func) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return funcCheck(fn()), nil
+	}
+}
+
+func floatToken(fn func(interface{}) Func) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		f, err := strconv.ParseFloat(n.arg(), 64)
+		if err != nil {
+			return nil, err
+		}
+		return funcCheck(fn(f)), nil
+	}
+}
+
+func intToken(fn func(int) Func) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		i, err := strconv.ParseInt(n.arg(), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return funcCheck(fn(int(i))), nil
+	}
+}
+
+// fieldToken returns a tokenFunc for tokens that compare their field to
+// another field of the same struct using c, or the negation of c.
+func fieldToken(c comparator, negate bool) tokenFunc {
+	return func(comp *compiler, n *tokenNode) (checkFunc, error) {
+		ref, err := comp.fieldRef(n.arg())
+		if err != nil {
+			return nil, err
+		}
+		return func(fc *fieldContext) error {
+			other, ok := ref.value(fc.parent)
+			if !ok || compareValues(c, fc.value, other) == negate {
+				return formatError(fc.name)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// requiredIfToken returns a tokenFunc for tokens whose arguments are
+// pairs of fields and values.  The field is required to be nonzero when
+// every pair matches, or when any doesn't if negate is set.  If exclude
+// is set the field is required to be zero instead.
+func requiredIfToken(negate, exclude bool) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		if len(n.args)%2 != 0 {
+			return nil, errors.New("arguments must be pairs of fields and values")
+		}
+		refs := []fieldRef{}
+		values := []string{}
+		for i := 0; i < len(n.args); i += 2 {
+			ref, err := c.fieldRef(n.args[i])
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+			values = append(values, n.args[i+1])
+		}
+		return conditionalCheck(exclude, func(parent reflect.Value) bool {
+			for i, ref := range refs {
+				v, ok := ref.value(parent)
+				if !ok || !equalsArg(v, values[i]) {
+					return negate
+				}
+			}
+			return !negate
+		}), nil
+	}
+}
+
+// requiredWithToken returns a tokenFunc for tokens whose arguments are
+// fields.  The field is required to be nonzero when any of them is
+// nonzero, or when any of them is zero if without is set.
+func requiredWithToken(without bool) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		refs := []fieldRef{}
+		for _, arg := range n.args {
+			ref, err := c.fieldRef(arg)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+		return conditionalCheck(false, func(parent reflect.Value) bool {
+			for _, ref := range refs {
+				v, ok := ref.value(parent)
+				if present := ok && !isZero(v); present != without {
+					return true
+				}
+			}
+			return false
+		}), nil
+	}
+}
+
+// conditionalCheck returns a checkFunc that requires its value to be
+// nonzero, or zero if exclude is set, when cond holds for the struct
+// holding the field.  Zero is defined as it is by Nonzero.
+func conditionalCheck(exclude bool, cond func(parent reflect.Value) bool) checkFunc {
+	return func(fc *fieldContext) error {
+		if cond(fc.parent) && isZero(fc.value) != exclude {
+			return formatError(fc.name)
+		}
+		return nil
+	}
+}
+
+// changeToken returns a tokenFunc for tokens that check how a field
+// changed.  allowed reports whether the field may change from old to
+// new.  Fields without an old value always pass.
+func changeToken(allowed func(old, new interface{}) bool) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		return func(fc *fieldContext) error {
+			if fc.hasOld && !allowed(fc.old, fc.value) {
+				return &ChangeError{Field: fc.name, From: fc.old, To: fc.value}
+			}
+			return nil
+		}, nil
+	}
+}
+
+// transitionToken parses arguments such as "draft>submitted" into the
+// edges of a state machine.  Changes must follow one of the edges.
+func transitionToken(c *compiler, n *tokenNode) (checkFunc, error) {
+	type edge struct{ from, to string }
+	edges := []edge{}
+	for _, arg := range n.args {
+		parts := strings.Split(arg, ">")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("transition %q must be written from>to", arg)
+		}
+		edges = append(edges, edge{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])})
+	}
+	return changeToken(func(old, new interface{}) bool {
+		if compareValues(eq, old, new) {
+			return true
+		}
+		for _, e := range edges {
+			if equalsArg(old, e.from) && equalsArg(new, e.to) {
+				return true
+			}
+		}
+		return false
+	})(c, n)
+}
+
+// eachCheck returns a checkFunc that runs rules against each element of
+// an array or slice.  Elements that are non-nil pointers are validated
+// by the value they point to.
+func eachCheck(rules []rule) checkFunc {
+	check := rulesCheck(rules)
+	return func(fc *fieldContext) error {
+		if !isArrayOrSlice(fc.value) {
+			return formatError(fc.name)
+		}
+		value := reflect.ValueOf(fc.value)
+		for i := 0; i < value.Len(); i++ {
+			efc := *fc
+			efc.value = pointee(value.Index(i)).Interface()
+			efc.old, efc.hasOld = nil, false
+			if err := check(&efc); err != nil {
+				return formatError(fc.name)
+			}
+		}
+		return nil
+	}
+}
+
+func argList(n *tokenNode) []interface{} {
+	list := []interface{}{}
+	for _, a := range n.args {
+		list = append(list, a)
+	}
+	return list
+}
@@ -0,0 +1,79 @@
+package gator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// ProblemContentType is the media type of a Problem encoded as JSON.
+const ProblemContentType = "application/problem+json"
+
+// A Problem describes the result of a validation as an RFC 7807 problem
+// details object.  The errors extension member lists each FieldError.
+type Problem struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Errors   ValidationErrors `json:"errors"`
+}
+
+// NewProblem returns a Problem describing err, an error returned from
+// Validate, with the status 422 Unprocessable Entity.  TagErrors are
+// programming errors, so they are described as a 500 Internal Server
+// Error without any errors.  A nil err returns nil.
+func NewProblem(err error) *Problem {
+	if err == nil {
+		return nil
+	}
+	var tErrs TagErrors
+	if errors.As(err, &tErrs) {
+		return newProblem(http.StatusInternalServerError, ValidationErrors{})
+	}
+	p := newProblem(http.StatusUnprocessableEntity, fieldErrors(err))
+	p.Detail = "The request did not pass validation."
+	return p
+}
+
+func newProblem(status int, errs ValidationErrors) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Errors: errs,
+	}
+}
+
+// MarshalJSON encodes the FieldError for clients as an object holding
+// its Path as a JSON Pointer, its Field, Code, Params and message, e.g.
+// {"pointer":"/Origin/Zip","field":"Origin.Zip","code":"gator.len",
+// "params":{"arg":5},"message":"Origin.Zip must have exactly 5 characters."}.
+func (e *FieldError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Pointer string                 `json:"pointer"`
+		Field   string                 `json:"field,omitempty"`
+		Code    string                 `json:"code"`
+		Params  map[string]interface{} `json:"params,omitempty"`
+		Message string                 `json:"message"`
+	}{
+		Pointer: e.Path.JSONPointer(),
+		Field:   e.Field,
+		Code:    e.Code,
+		Params:  e.Params,
+		Message: e.Error(),
+	})
+}
+
+// Values returns the messages of the FieldErrors held by err keyed by
+// Field, e.g. for redisplaying a form.  Messages of errors that don't
+// belong to a field are keyed by "".
+func Values(err error) url.Values {
+	v := url.Values{}
+	for _, fErr := range fieldErrors(err) {
+		v.Add(fErr.Field, fErr.Error())
+	}
+	return v
+}
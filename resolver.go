@@ -0,0 +1,297 @@
+package gator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultLookupWorkers is the number of lookups run at once by a
+// validation unless WithLookupWorkers is used.
+const DefaultLookupWorkers = 4
+
+// A Resolver looks up values for the exists and unique tokens, e.g. in a
+// database.  source is the token's argument, e.g. "carriers.dot" for
+// `gator:"exists(carriers.dot)"`.  Resolvers must be safe for concurrent
+// use and should return promptly once ctx is done.
+type Resolver interface {
+	Exists(ctx context.Context, source string, value interface{}) (bool, error)
+}
+
+// ResolverFunc is an adapter that allows the use of an ordinary function
+// as a Resolver.
+type ResolverFunc func(ctx context.Context, source string, value interface{}) (bool, error)
+
+// Exists calls f(ctx, source, value).
+func (f ResolverFunc) Exists(ctx context.Context, source string, value interface{}) (bool, error) {
+	return f(ctx, source, value)
+}
+
+// WithResolver returns an option that makes the exists and unique tokens
+// look values up using r.  Without a Resolver they always fail.
+func WithResolver(r Resolver) func(*Gator) {
+	return func(g *Gator) {
+		g.lookup.resolver = r
+	}
+}
+
+// WithLookupWorkers returns an option that limits the number of lookups
+// run at once by a validation to n.  The default is
+// DefaultLookupWorkers.
+func WithLookupWorkers(n int) func(*Gator) {
+	return func(g *Gator) {
+		g.lookup.workers = n
+	}
+}
+
+// WithLookupTimeout returns an option that limits each lookup to d.  A
+// lookup that times out fails its field.  There is no limit by default.
+func WithLookupTimeout(d time.Duration) func(*Gator) {
+	return func(g *Gator) {
+		g.lookup.timeout = d
+	}
+}
+
+// lookupConfig holds the options of lookup tokens.
+type lookupConfig struct {
+	resolver Resolver
+	workers  int
+	timeout  time.Duration
+}
+
+// lookupToken creates the checkFunc of exists, if want is set, or
+// unique.  Zero values pass without a lookup.
+func lookupToken(want bool) tokenFunc {
+	return func(c *compiler, n *tokenNode) (checkFunc, error) {
+		source := n.arg()
+		return func(fc *fieldContext) error {
+			if isZero(fc.value) {
+				return nil
+			}
+			if fc.lookup == nil || fc.lookup.resolver == nil {
+				return fmt.Errorf("gator: can't look up %s without a Resolver", fc.name)
+			}
+			ctx := fc.context()
+			if fc.lookup.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, fc.lookup.timeout)
+				defer cancel()
+			}
+			found, err := fc.lookup.resolver.Exists(ctx, source, fc.value)
+			if err != nil {
+				return fmt.Errorf("gator: couldn't look up %s in %s - %s", fc.name, source, err)
+			}
+			if found != want {
+				return formatError(fc.name)
+			}
+			return nil
+		}, nil
+	}
+}
+
+// pendingLookup is a rule of a lookup token that is run after the value
+// has been walked.
+type pendingLookup struct {
+	rule rule
+	fc   *fieldContext
+	// pos is the number of errors found before the rule.
+	pos int
+}
+
+// resolve runs the pending lookups of the run using a pool of workers and
+// inserts their errors in the order they would have been found.
+func (r *planRun) resolve() {
+	if len(r.pending) == 0 || r.ctxErr != nil || len(r.tagErrs) > 0 {
+		return
+	}
+	errs := make([]*FieldError, len(r.pending))
+	jobs := make(chan int)
+	workers := r.lookup.workers
+	if workers <= 0 {
+		workers = DefaultLookupWorkers
+	}
+	wg := sync.WaitGroup{}
+	for w := 0; w < workers && w < len(r.pending); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				p := r.pending[i]
+				errs[i], _ = p.rule.run(p.fc)
+			}
+		}()
+	}
+	for i := range r.pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	if r.canceled() {
+		return
+	}
+	// insert from the end so that earlier positions stay valid
+	for i := len(r.pending) - 1; i >= 0; i-- {
+		if errs[i] == nil {
+			continue
+		}
+		pos := r.pending[i].pos
+		r.errs = append(r.errs[:pos], append(ValidationErrors{errs[i]}, r.errs[pos:]...)...)
+	}
+}
+
+// A MemoryResolver is a Resolver that holds its sources in memory, e.g.
+// for tests.  Values are compared by their fmt.Sprint form.
+type MemoryResolver struct {
+	mu      sync.RWMutex
+	sources map[string]map[string]bool
+}
+
+// NewMemoryResolver returns an empty MemoryResolver.
+func NewMemoryResolver() *MemoryResolver {
+	return &MemoryResolver{sources: map[string]map[string]bool{}}
+}
+
+// Add adds values to source.
+func (m *MemoryResolver) Add(source string, values ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.sources[source] == nil {
+		m.sources[source] = map[string]bool{}
+	}
+	for _, v := range values {
+		m.sources[source][fmt.Sprint(v)] = true
+	}
+}
+
+// Remove removes values from source.
+func (m *MemoryResolver) Remove(source string, values ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range values {
+		delete(m.sources[source], fmt.Sprint(v))
+	}
+}
+
+// Exists implements the Resolver interface.
+func (m *MemoryResolver) Exists(ctx context.Context, source string, value interface{}) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.sources[source][fmt.Sprint(value)], nil
+}
+
+// NewCachingResolver returns a Resolver that remembers the results of r
+// for ttl so that repeated values aren't looked up again.  Concurrent
+// lookups of the same value share one call to r.  Errors aren't
+// remembered, and if the shared call fails because its caller's context
+// is done, the other callers look the value up again.  Values are
+// compared by their fmt.Sprint form.
+func NewCachingResolver(r Resolver, ttl time.Duration) Resolver {
+	return &cachingResolver{r: r, ttl: ttl, entries: map[cacheKey]*cacheEntry{}}
+}
+
+type cachingResolver struct {
+	r       Resolver
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+	swept   time.Time
+}
+
+type cacheKey struct {
+	source string
+	value  string
+}
+
+// cacheEntry holds the result of a lookup once done is closed.
+type cacheEntry struct {
+	done    chan struct{}
+	found   bool
+	err     error
+	expires time.Time
+	// abandoned is set if the lookup failed because the context of its
+	// caller was done.
+	abandoned bool
+}
+
+func (c *cachingResolver) Exists(ctx context.Context, source string, value interface{}) (bool, error) {
+	key := cacheKey{source: source, value: fmt.Sprint(value)}
+	for {
+		now := time.Now()
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		if ok && e.resolved() && now.After(e.expires) {
+			ok = false
+		}
+		if !ok {
+			c.sweep(now)
+			e = &cacheEntry{done: make(chan struct{})}
+			c.entries[key] = e
+			c.mu.Unlock()
+			return c.lookup(ctx, key, e, source, value)
+		}
+		c.mu.Unlock()
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		// the caller that looked the value up gave up, which shouldn't
+		// fail the others
+		if e.abandoned && ctx.Err() == nil {
+			continue
+		}
+		return e.found, e.err
+	}
+}
+
+// lookup looks the value of key up using the wrapped Resolver and
+// completes e.  Failed lookups, including those that panic, are removed
+// from the cache.
+func (c *cachingResolver) lookup(ctx context.Context, key cacheKey, e *cacheEntry, source string, value interface{}) (found bool, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			e.found, e.err = false, fmt.Errorf("gator: Resolver panicked - %v", p)
+		}
+		e.expires = time.Now().Add(c.ttl)
+		if e.err != nil {
+			e.abandoned = ctx.Err() != nil
+			c.mu.Lock()
+			if c.entries[key] == e {
+				delete(c.entries, key)
+			}
+			c.mu.Unlock()
+		}
+		close(e.done)
+		found, err = e.found, e.err
+	}()
+	e.found, e.err = c.r.Exists(ctx, source, value)
+	return
+}
+
+// sweep removes expired entries at most once per ttl.  c.mu must be
+// held.
+func (c *cachingResolver) sweep(now time.Time) {
+	if now.Sub(c.swept) < c.ttl {
+		return
+	}
+	c.swept = now
+	for key, e := range c.entries {
+		if e.resolved() && now.After(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// resolved reports whether the lookup of e is done.
+func (e *cacheEntry) resolved() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
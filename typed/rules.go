@@ -0,0 +1,175 @@
+package typed
+
+import "regexp"
+
+// Number is the set of built-in number types and types based on them.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Required returns a Rule that validates its value isn't the zero value
+// of T.  It is equivalent to nonzero.
+func Required[T comparable]() Rule[T] {
+	return func(name string, v T) error {
+		var zero T
+		if v == zero {
+			return fail(name, "nonzero")
+		}
+		return nil
+	}
+}
+
+// Eq returns a Rule that validates its value equals want.
+func Eq[T comparable](want T) Rule[T] {
+	return func(name string, v T) error {
+		if v != want {
+			return fail(name, "eq", want)
+		}
+		return nil
+	}
+}
+
+// OneOf returns a Rule that validates its value is one of values.  It is
+// equivalent to in.
+func OneOf[T comparable](values ...T) Rule[T] {
+	return func(name string, v T) error {
+		for _, value := range values {
+			if v == value {
+				return nil
+			}
+		}
+		return fail(name, "in", anys(values)...)
+	}
+}
+
+// NoneOf returns a Rule that validates its value isn't one of values.  It
+// is equivalent to notin.
+func NoneOf[T comparable](values ...T) Rule[T] {
+	return func(name string, v T) error {
+		for _, value := range values {
+			if v == value {
+				return fail(name, "notin", anys(values)...)
+			}
+		}
+		return nil
+	}
+}
+
+// Min returns a Rule that validates its value is greater than or equal
+// to n.  It is equivalent to gte.
+func Min[T Number](n T) Rule[T] {
+	return func(name string, v T) error {
+		if v < n {
+			return fail(name, "gte", n)
+		}
+		return nil
+	}
+}
+
+// Max returns a Rule that validates its value is less than or equal to
+// n.  It is equivalent to lte.
+func Max[T Number](n T) Rule[T] {
+	return func(name string, v T) error {
+		if v > n {
+			return fail(name, "lte", n)
+		}
+		return nil
+	}
+}
+
+// Gt returns a Rule that validates its value is greater than n.
+func Gt[T Number](n T) Rule[T] {
+	return func(name string, v T) error {
+		if v <= n {
+			return fail(name, "gt", n)
+		}
+		return nil
+	}
+}
+
+// Lt returns a Rule that validates its value is less than n.
+func Lt[T Number](n T) Rule[T] {
+	return func(name string, v T) error {
+		if v >= n {
+			return fail(name, "lt", n)
+		}
+		return nil
+	}
+}
+
+// Len returns a Rule that validates the length of its value in bytes is
+// l.
+func Len[T ~string](l int) Rule[T] {
+	return func(name string, v T) error {
+		if len(v) != l {
+			return fail(name, "len", l)
+		}
+		return nil
+	}
+}
+
+// MinLen returns a Rule that validates the length of its value in bytes
+// is at least l.
+func MinLen[T ~string](l int) Rule[T] {
+	return func(name string, v T) error {
+		if len(v) < l {
+			return fail(name, "minlen", l)
+		}
+		return nil
+	}
+}
+
+// MaxLen returns a Rule that validates the length of its value in bytes
+// is at most l.
+func MaxLen[T ~string](l int) Rule[T] {
+	return func(name string, v T) error {
+		if len(v) > l {
+			return fail(name, "maxlen", l)
+		}
+		return nil
+	}
+}
+
+// MinItems returns a Rule that validates its value has at least n
+// elements.  It is equivalent to minlen.
+func MinItems[S ~[]E, E any](n int) Rule[S] {
+	return func(name string, v S) error {
+		if len(v) < n {
+			return fail(name, "minlen", n)
+		}
+		return nil
+	}
+}
+
+// MaxItems returns a Rule that validates its value has at most n
+// elements.  It is equivalent to maxlen.
+func MaxItems[S ~[]E, E any](n int) Rule[S] {
+	return func(name string, v S) error {
+		if len(v) > n {
+			return fail(name, "maxlen", n)
+		}
+		return nil
+	}
+}
+
+// Matches returns a Rule that validates its value matches expr.  It
+// panics if expr can't be compiled.
+func Matches[T ~string](expr string) Rule[T] {
+	re := regexp.MustCompile(expr)
+	return func(name string, v T) error {
+		if !re.MatchString(string(v)) {
+			return fail(name, "matches", expr)
+		}
+		return nil
+	}
+}
+
+func anys[T any](values []T) []interface{} {
+	a := make([]interface{}, len(values))
+	for i, v := range values {
+		a[i] = v
+	}
+	return a
+}
@@ -0,0 +1,139 @@
+// Package typed is a generic counterpart of gator's Funcs.  Rules are
+// typed, so a rule that doesn't fit a value is caught by the compiler
+// rather than failing at runtime, and values aren't converted to
+// interfaces unless they fail:
+//
+//	g := gator.New(gator.WithMode(gator.AllErrors)).Add(
+//		typed.NewField("Age", user.Age, typed.Min(18)),
+//		typed.NewField("Name", user.Name, typed.Required[string](), typed.MaxLen[string](64)),
+//	)
+//
+// Fields are gator.Validators and fail with a *gator.FieldError carrying
+// the message, Code and Params of the equivalent gator tag token.
+// Messages are rendered with the Registry and locale of the Gator a
+// Field is added to.
+package typed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ShaleApps/gator"
+)
+
+// A Rule validates a value of type T.  It returns nil if v is valid.
+type Rule[T any] func(name string, v T) error
+
+// Func returns a gator.Func that validates values of type T using r,
+// e.g. for gator.NewField or gator.RegisterStructTagToken.  Values of
+// other types fail.
+func (r Rule[T]) Func() gator.Func {
+	return func(name string, v interface{}) error {
+		t, ok := v.(T)
+		if !ok {
+			return fmt.Errorf("%s must be of type %s.", name, reflect.TypeOf((*T)(nil)).Elem())
+		}
+		return r(name, t)
+	}
+}
+
+// FromFunc returns a Rule that validates values using f, e.g.
+// gator.Email().
+func FromFunc[T any](f gator.Func) Rule[T] {
+	return func(name string, v T) error {
+		return f(name, v)
+	}
+}
+
+// A Field is a named value of type T that is validated against Rules.
+type Field[T any] struct {
+	name  string
+	value T
+	rules []Rule[T]
+}
+
+// NewField creates an initialized Field.
+func NewField[T any](name string, value T, rules ...Rule[T]) *Field[T] {
+	return &Field[T]{name: name, value: value, rules: rules}
+}
+
+// Validate implements the gator.Validator interface.  The first Rule
+// that fails is reported as a *gator.FieldError.  Rules of this package
+// are reported with the message of their token in the default Registry,
+// e.g. "Age must be greater than or equal to 18.", while the errors of
+// other Rules, and the panics they raise, are reported as is.
+func (f *Field[T]) Validate() error {
+	return f.ValidateMessages(context.Background(), gator.DefaultRegistry(), "")
+}
+
+// ValidateMessages implements the gator.MessageValidator interface.  It
+// is like Validate but renders messages using reg in locale, so Fields
+// added to a Gator follow its Registry and locale.
+func (f *Field[T]) ValidateMessages(ctx context.Context, reg *gator.Registry, locale string) error {
+	for _, r := range f.rules {
+		if err := f.run(r); err != nil {
+			return f.fieldError(err, reg, locale)
+		}
+	}
+	return nil
+}
+
+func (f *Field[T]) run(r Rule[T]) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("gator: Rule panicked while validating %s - %v", f.name, p)
+		}
+	}()
+	return r(f.name, f.value)
+}
+
+func (f *Field[T]) fieldError(err error, reg *gator.Registry, locale string) *gator.FieldError {
+	fErr := &gator.FieldError{
+		Field: f.name,
+		Path:  gator.Path{{Field: f.name}},
+		Code:  "gator.invalid",
+		Value: f.value,
+		Err:   err,
+	}
+	var fail *failure
+	if !errors.As(err, &fail) {
+		return fErr
+	}
+	args := make([]string, len(fail.args))
+	for i, arg := range fail.args {
+		args[i] = fmt.Sprint(arg)
+	}
+	fErr.Token = fail.token
+	fErr.Arg = strings.Join(args, ",")
+	fErr.Code = "gator." + fail.token
+	switch {
+	case len(fail.args) == 1:
+		fErr.Params = map[string]interface{}{"arg": fail.args[0]}
+	case len(fail.args) > 1:
+		fErr.Params = map[string]interface{}{"args": fail.args}
+	}
+	data := gator.MessageData{Field: f.name, Token: fail.token, Arg: fErr.Arg, Args: args, Value: f.value}
+	if msg, ok := reg.Message(locale, data); ok {
+		fErr.Err = errors.New(msg)
+	}
+	return fErr
+}
+
+// failure is returned by the Rules of this package.  It is replaced by
+// the message of the gator tag token the Rule is equivalent to.
+type failure struct {
+	name  string
+	token string
+	args  []interface{}
+}
+
+func fail(name, token string, args ...interface{}) error {
+	return &failure{name: name, token: token, args: args}
+}
+
+func (e *failure) Error() string {
+	return e.name + " did not pass validation."
+}
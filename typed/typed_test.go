@@ -0,0 +1,151 @@
+package typed_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ShaleApps/gator"
+	"github.com/ShaleApps/gator/typed"
+)
+
+type miles float64
+
+type trailer string
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		v    gator.Validator
+		msg  string
+		code string
+	}{
+		{typed.NewField("Age", 21, typed.Min(18)), "", ""},
+		{typed.NewField("Age", 17, typed.Min(18)), "Age must be greater than or equal to 18.", "gator.gte"},
+		{typed.NewField("Distance", miles(600), typed.Gt[miles](0), typed.Max[miles](500)),
+			"Distance must be less than or equal to 500.", "gator.lte"},
+		{typed.NewField("Weight", 0, typed.Gt(0)), "Weight must be greater than 0.", "gator.gt"},
+		{typed.NewField("Temp", 40.5, typed.Lt(32.0)), "Temp must be less than 32.", "gator.lt"},
+		{typed.NewField("Name", "", typed.Required[string](), typed.MaxLen[string](10)), "Name is required.", "gator.nonzero"},
+		{typed.NewField("Name", "Longhorn Freight", typed.Required[string](), typed.MaxLen[string](10)),
+			"Name must have at most 10 characters.", "gator.maxlen"},
+		{typed.NewField("State", "T", typed.Len[string](2)), "State must have exactly 2 characters.", "gator.len"},
+		{typed.NewField("Ref", "A", typed.MinLen[string](3)), "Ref must have at least 3 characters.", "gator.minlen"},
+		{typed.NewField("Stops", []string{"a"}, typed.MinItems[[]string](2)), "Stops must have at least 2 items.", "gator.minlen"},
+		{typed.NewField("Stops", []string{"a", "b"}, typed.MaxItems[[]string](1)), "Stops must have at most 1 item.", "gator.maxlen"},
+		{typed.NewField("Type", trailer("flatbed"), typed.OneOf[trailer]("dry", "reefer")),
+			"Type must be one of dry, reefer.", "gator.in"},
+		{typed.NewField("Type", trailer("dry"), typed.NoneOf[trailer]("dry", "reefer")),
+			"Type must not be one of dry, reefer.", "gator.notin"},
+		{typed.NewField("Axles", 4, typed.Eq(5)), "Axles must equal 5.", "gator.eq"},
+		{typed.NewField("Zip", "7870", typed.Matches[string](`^\d{5}$`)), `Zip must match ^\d{5}$.`, "gator.matches"},
+	}
+	for _, test := range tests {
+		err := test.v.Validate()
+		if test.msg == "" {
+			if err != nil {
+				t.Errorf("unexpected error %s", err)
+			}
+			continue
+		}
+		var fErr *gator.FieldError
+		switch {
+		case !errors.As(err, &fErr):
+			t.Errorf("expected a *FieldError for %q, got %v", test.msg, err)
+		case err.Error() != test.msg:
+			t.Errorf("expected %q, got %q", test.msg, err)
+		case fErr.Code != test.code:
+			t.Errorf("expected code %s, got %s", test.code, fErr.Code)
+		}
+	}
+}
+
+func TestFieldError(t *testing.T) {
+	var fErr *gator.FieldError
+	err := typed.NewField("Age", 17, typed.Min(18)).Validate()
+	if !errors.As(err, &fErr) {
+		t.Fatalf("expected a *FieldError, got %v", err)
+	}
+	if fErr.Field != "Age" || fErr.Path.String() != "Age" || fErr.Token != "gte" || fErr.Arg != "18" ||
+		fErr.Value != 17 || !reflect.DeepEqual(fErr.Params, map[string]interface{}{"arg": 18}) {
+		t.Errorf("unexpected error %#v", fErr)
+	}
+	err = typed.NewField("Type", "flatbed", typed.OneOf("dry", "reefer")).Validate()
+	if !errors.As(err, &fErr) || fErr.Arg != "dry,reefer" ||
+		!reflect.DeepEqual(fErr.Params, map[string]interface{}{"args": []interface{}{"dry", "reefer"}}) {
+		t.Errorf("unexpected error %#v", err)
+	}
+
+	custom := errors.New("Age must be a prime number.")
+	err = typed.NewField("Age", 21, func(name string, v int) error {
+		for i := 2; i*i <= v; i++ {
+			if v%i == 0 {
+				return custom
+			}
+		}
+		return nil
+	}).Validate()
+	if !errors.As(err, &fErr) || fErr.Err != custom || fErr.Code != "gator.invalid" || fErr.Token != "" {
+		t.Errorf("custom rules should be reported as is, got %#v", err)
+	}
+
+	err = typed.NewField("Stops", []string(nil), func(name string, v []string) error {
+		_ = v[0]
+		return nil
+	}).Validate()
+	if err == nil || err.Error() != "gator: Rule panicked while validating Stops - runtime error: index out of range [0] with length 0" {
+		t.Errorf("expected a panic to be reported, got %v", err)
+	}
+}
+
+func TestGator(t *testing.T) {
+	g := gator.New(gator.WithMode(gator.AllErrors)).Add(
+		typed.NewField("Age", 17, typed.Min(18)),
+		typed.NewField("Name", "", typed.Required[string]()),
+		typed.NewField("Distance", miles(10), typed.Max[miles](500)),
+		gator.NewField("Email", "hi", gator.Email()),
+	)
+	err := g.Validate()
+	expected := "Age must be greater than or equal to 18.\nName is required.\nEmail did not pass validation."
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	var vErrs gator.ValidationErrors
+	if !errors.As(err, &vErrs) || len(vErrs) != 3 || vErrs[0].Code != "gator.gte" || vErrs[1].Code != "gator.nonzero" {
+		t.Errorf("unexpected errors %#v", err)
+	}
+
+	expected = "Age debe ser mayor o igual que 18.\nName es obligatorio.\nEmail did not pass validation."
+	if err := g.ValidateLocale("es"); err == nil || err.Error() != expected {
+		t.Errorf("expected:\n%s\ngot:\n%v", expected, err)
+	}
+	r := gator.NewRegistry()
+	if err := r.SetMessage("gte", "{{.Field}} is too young."); err != nil {
+		t.Fatal(err)
+	}
+	err = gator.New(gator.WithRegistry(r)).Add(typed.NewField("Age", 17, typed.Min(18))).Validate()
+	if err == nil || err.Error() != "Age is too young." {
+		t.Errorf("expected the Gator's Registry to be used, got %v", err)
+	}
+}
+
+func TestAdapters(t *testing.T) {
+	f := typed.Min(5).Func()
+	if err := gator.NewField("Axles", 6, f).Validate(); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	if err := gator.NewField("Axles", 4, f).Validate(); err == nil {
+		t.Error("expected an error for 4")
+	}
+	if err := gator.NewField("Axles", "six", f).Validate(); err == nil || err.Error() != "Axles must be of type int." {
+		t.Errorf("expected a type error, got %v", err)
+	}
+
+	r := typed.FromFunc[string](gator.Email())
+	if err := typed.NewField("Email", "dispatch@example.com", r).Validate(); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	err := typed.NewField("Email", "dispatch", r).Validate()
+	if err == nil || err.Error() != "Email did not pass validation." {
+		t.Errorf("expected an email error, got %v", err)
+	}
+}
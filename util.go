@@ -2,15 +2,25 @@ package gator
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
+	"time"
 )
 
 func getReflectInfo(src interface{}) (reflect.Type, reflect.Value, error) {
+	if src == nil {
+		return nil, reflect.Value{}, errors.New("gator: src must be a struct or a pointer to a struct, not nil")
+	}
 	objT := reflect.TypeOf(src)
 	objV := reflect.ValueOf(src)
 	switch {
 	case isStruct(objT):
 	case isStructPtr(objT):
+		if objV.IsNil() {
+			return objT, objV, errors.New("gator: src is a nil pointer")
+		}
 		objT = objT.Elem()
 		objV = objV.Elem()
 	default:
@@ -50,3 +60,302 @@ func lengthOf(a interface{}) (int, bool) {
 		return 0, false
 	}
 }
+
+// copyValue returns a copy of v that can be used with Interface even
+// if v was obtained through an unexported field.  Only booleans,
+// numbers, strings and pointers, slices and arrays of them can be
+// copied.
+func copyValue(v reflect.Value) (reflect.Value, bool) {
+	if v.CanInterface() {
+		return v, true
+	}
+	t := v.Type()
+	c := reflect.New(t).Elem()
+	switch v.Kind() {
+	case reflect.Bool:
+		c.SetBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		c.SetInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		c.SetUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		c.SetFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c.SetComplex(v.Complex())
+	case reflect.String:
+		c.SetString(v.String())
+	case reflect.Ptr:
+		if v.IsNil() {
+			return c, true
+		}
+		e, ok := copyValue(v.Elem())
+		if !ok {
+			return c, false
+		}
+		c.Set(reflect.New(t.Elem()))
+		c.Elem().Set(e)
+	case reflect.Slice:
+		if v.IsNil() {
+			return c, true
+		}
+		c.Set(reflect.MakeSlice(t, v.Len(), v.Len()))
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			e, ok := copyValue(v.Index(i))
+			if !ok {
+				return c, false
+			}
+			c.Index(i).Set(e)
+		}
+	default:
+		return c, false
+	}
+	return c, true
+}
+
+// comparator is a numerical comparison.
+type comparator int
+
+const (
+	eq comparator = iota
+	gt
+	gte
+	lt
+	lte
+)
+
+type numberKind int
+
+const (
+	intKind numberKind = iota
+	uintKind
+	floatKind
+)
+
+// number holds a built-in number type as the widest type of its kind.
+type number struct {
+	kind numberKind
+	i    int64
+	u    uint64
+	f    float64
+}
+
+// toNumber converts v, which may be any integer or float type, into a
+// number.
+func toNumber(v interface{}) (number, bool) {
+	switch n := v.(type) {
+	case int:
+		return number{kind: intKind, i: int64(n)}, true
+	case int8:
+		return number{kind: intKind, i: int64(n)}, true
+	case int16:
+		return number{kind: intKind, i: int64(n)}, true
+	case int32:
+		return number{kind: intKind, i: int64(n)}, true
+	case int64:
+		return number{kind: intKind, i: n}, true
+	case uint:
+		return number{kind: uintKind, u: uint64(n)}, true
+	case uint8:
+		return number{kind: uintKind, u: uint64(n)}, true
+	case uint16:
+		return number{kind: uintKind, u: uint64(n)}, true
+	case uint32:
+		return number{kind: uintKind, u: uint64(n)}, true
+	case uint64:
+		return number{kind: uintKind, u: n}, true
+	case float32:
+		return number{kind: floatKind, f: float64(n)}, true
+	case float64:
+		return number{kind: floatKind, f: n}, true
+	case nil:
+		return number{}, false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: intKind, i: rv.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number{kind: uintKind, u: rv.Uint()}, true
+	case reflect.Float32, reflect.Float64:
+		return number{kind: floatKind, f: rv.Float()}, true
+	}
+	return number{}, false
+}
+
+func (n number) float() float64 {
+	switch n.kind {
+	case intKind:
+		return float64(n.i)
+	case uintKind:
+		return float64(n.u)
+	}
+	return n.f
+}
+
+func (n number) uint() uint64 {
+	if n.kind == intKind {
+		return uint64(n.i)
+	}
+	return n.u
+}
+
+// compareNumbers reports whether a compares to b using c.  Floats are
+// compared if either number is a float, otherwise integers are
+// compared exactly, even if one is signed and the other isn't.
+func compareNumbers(c comparator, a, b number) bool {
+	var less, greater bool
+	switch {
+	case a.kind == floatKind || b.kind == floatKind:
+		x, y := a.float(), b.float()
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return false
+		}
+		less, greater = x < y, x > y
+	case a.kind == intKind && b.kind == intKind:
+		less, greater = a.i < b.i, a.i > b.i
+	case a.kind == intKind && a.i < 0:
+		less = true
+	case b.kind == intKind && b.i < 0:
+		greater = true
+	default:
+		less, greater = a.uint() < b.uint(), a.uint() > b.uint()
+	}
+	return compareOrder(c, less, greater)
+}
+
+// compareValues reports whether a compares to b using c.  Numbers,
+// strings and time.Times are ordered, pointers to them are followed.
+// Other values can only be compared for equality, using
+// reflect.DeepEqual.
+func compareValues(c comparator, a, b interface{}) bool {
+	x, y := indirect(reflect.ValueOf(a)), indirect(reflect.ValueOf(b))
+	if !x.IsValid() || !y.IsValid() {
+		return c == eq && !x.IsValid() && !y.IsValid()
+	}
+	if m, ok := toNumber(x.Interface()); ok {
+		n, ok := toNumber(y.Interface())
+		return ok && compareNumbers(c, m, n)
+	}
+	if x.Type() == timeType && y.Type() == timeType {
+		s, t := x.Interface().(time.Time), y.Interface().(time.Time)
+		return compareOrder(c, s.Before(t), s.After(t))
+	}
+	if x.Kind() == reflect.String && y.Kind() == reflect.String {
+		return compareOrder(c, x.String() < y.String(), x.String() > y.String())
+	}
+	return c == eq && reflect.DeepEqual(x.Interface(), y.Interface())
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// indirect follows pointers until it finds a value that isn't one.  It
+// returns the zero Value if a nil pointer is found.
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// compareOrder reports whether the result of comparing two values,
+// given as whether the first is less than or greater than the second,
+// satisfies c.
+func compareOrder(c comparator, less, greater bool) bool {
+	switch c {
+	case eq:
+		return !less && !greater
+	case gt:
+		return greater
+	case gte:
+		return !less
+	case lt:
+		return less
+	case lte:
+		return !greater
+	}
+	return false
+}
+
+// parseNumber parses s as an integer, or as a float if it isn't one.
+func parseNumber(s string) (number, bool) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return number{kind: intKind, i: i}, true
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return number{kind: uintKind, u: u}, true
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return number{kind: floatKind, f: f}, true
+	}
+	return number{}, false
+}
+
+// equalsArg reports whether v, or the value it points to, equals arg, a
+// struct tag argument.  Numbers and booleans are compared after parsing
+// arg, other values are compared as strings.
+func equalsArg(v interface{}, arg string) bool {
+	rv := indirect(reflect.ValueOf(v))
+	if !rv.IsValid() {
+		return false
+	}
+	if n, ok := toNumber(rv.Interface()); ok {
+		a, ok := parseNumber(arg)
+		return ok && compareNumbers(eq, n, a)
+	}
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String() == arg
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		return err == nil && rv.Bool() == b
+	}
+	s, ok := toString(rv.Interface())
+	return ok && s == arg
+}
+
+// isZero reports whether v is nil or the zero value of its type.
+func isZero(v interface{}) bool {
+	switch z := v.(type) {
+	case nil:
+		return true
+	case string:
+		return z == ""
+	case int:
+		return z == 0
+	case int64:
+		return z == 0
+	case float64:
+		return z == 0
+	case bool:
+		return !z
+	}
+	return reflect.ValueOf(v).IsZero()
+}
+
+// isNil reports whether v is nil or a nil pointer.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// toString returns the string held by v, which may be a string, byte
+// slice or fmt.Stringer.
+func toString(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	case fmt.Stringer:
+		return s.String(), true
+	}
+	return "", false
+}
@@ -0,0 +1,458 @@
+package gator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// planRun validates a value using structPlans.  It walks the value and
+// every struct reachable from it through fields, pointers, interfaces,
+// slices, arrays and maps.  Field names are paths from the root struct
+// such as "Shipments[3].Origin.Zip".  Fields of embedded structs are
+// promoted and don't include the embedded type's name.
+type planRun struct {
+	ctx    context.Context
+	cfg    compileConfig
+	mode   Mode
+	groups []string
+	locale string
+	// root is the struct being validated.
+	root reflect.Value
+	// fields selects the fields to validate.  Every field is validated
+	// if it is nil.
+	fields fieldSet
+	errs   ValidationErrors
+	// tagErrs holds the TagErrors of struct types that are only found
+	// while walking, e.g. those held in interfaces.
+	tagErrs TagErrors
+	// sp is the plan of the root struct and tagged holds the plans
+	// whose TagErrors have been reported, see structPlan.
+	sp     *structPlan
+	tagged map[*structPlan]bool
+	// embedded is set while walking into an embedded struct.
+	embedded bool
+	// ctxErr is set once ctx is done.
+	ctxErr *ContextError
+	// pending holds the rules of lookup tokens that are run using
+	// lookup once the value has been walked.  See resolve.
+	lookup  lookupConfig
+	pending []pendingLookup
+	// visited holds the pointers, maps and slices being walked so
+	// that cycles aren't followed.  Values shared by several fields are
+	// walked for each of them.
+	visited map[visit]bool
+}
+
+type visit struct {
+	ptr uintptr
+	// len is the length of a slice, which shares its pointer with the
+	// slices of other lengths taken from the same array.
+	len int
+	typ reflect.Type
+}
+
+func newPlanRun(ctx context.Context, p *Plan, locale string) *planRun {
+	return &planRun{ctx: ctx, cfg: p.cfg, mode: p.mode, groups: p.groups, fields: p.fields, locale: locale, lookup: p.lookup, sp: p.sp}
+}
+
+// err returns the result of the run.
+func (r *planRun) err() error {
+	switch {
+	case r.ctxErr != nil:
+		return r.ctxErr
+	case len(r.tagErrs) > 0:
+		return r.tagErrs
+	case len(r.errs) == 0:
+		return nil
+	case r.mode != AllErrors:
+		return r.errs[0]
+	}
+	return r.errs
+}
+
+// done reports whether the run should stop walking.
+func (r *planRun) done() bool {
+	return r.ctxErr != nil || len(r.tagErrs) > 0 || (r.mode != AllErrors && len(r.errs) > 0)
+}
+
+// canceled reports whether the run's context is done and records its
+// error if so.
+func (r *planRun) canceled() bool {
+	if r.ctxErr == nil && r.ctx.Done() != nil {
+		if err := r.ctx.Err(); err != nil {
+			r.ctxErr = &ContextError{Err: err}
+		}
+	}
+	return r.ctxErr != nil
+}
+
+// walkStruct checks the rules of v, a struct, and walks into each of
+// its fields.  Only the fields in sel are checked unless sel is nil.
+// old is the previous value of v when validating a change and is
+// invalid otherwise.
+func (r *planRun) walkStruct(path Path, v, old reflect.Value, sp *structPlan, sel fieldSet) {
+	embedded := r.embedded
+	r.embedded = false
+	for _, fp := range sp.fields {
+		fv := v.Field(fp.index)
+		var oldFv reflect.Value
+		if old.IsValid() {
+			oldFv = old.Field(fp.index)
+		}
+		fpath := path.field(fp.pathName)
+		child, selected := sel[fp.name]
+		promoted := false
+		switch {
+		case sel == nil || selected:
+		case fp.promoted:
+			// promoted fields are selected by their own names
+			child, promoted = sel, true
+		default:
+			continue
+		}
+		if len(fp.rules) > 0 && !promoted {
+			if value, ok := r.value(pointee(fv)); ok {
+				fc := &fieldContext{
+					name:   fpath.String(),
+					path:   fpath,
+					value:  value,
+					parent: v,
+					root:   r.root,
+					field:  fp.field,
+					reg:    r.cfg.reg,
+					locale: r.locale,
+					ctx:    r.ctx,
+					lookup: &r.lookup,
+				}
+				if oldFv.IsValid() {
+					fc.old, fc.hasOld = r.value(pointee(oldFv))
+				}
+				for _, rl := range fp.rules {
+					if !rl.in(r.groups) {
+						continue
+					}
+					if rl.lookup && r.lookup.resolver != nil {
+						r.pending = append(r.pending, pendingLookup{rule: rl, fc: fc, pos: len(r.errs)})
+						continue
+					}
+					fErr, skip := rl.run(fc)
+					if r.canceled() {
+						// the rule may have failed because of it
+						return
+					}
+					if fErr != nil {
+						r.errs = append(r.errs, fErr)
+						if r.done() {
+							return
+						}
+					}
+					if skip {
+						break
+					}
+				}
+			}
+		}
+		if !fp.walk {
+			continue
+		}
+		switch {
+		case fp.anonymous:
+			// the exported fields of unexported embedded structs
+			// are still promoted.  Embedded structs the FieldNamer
+			// names are nested, as encoding/json does.
+			epath := path
+			if !fp.promoted {
+				epath = fpath
+			}
+			r.embedded = true
+			r.walk(epath, fv, oldFv, fp.plan, child)
+			r.embedded = false
+		case fv.CanInterface() || r.cfg.unexported:
+			r.walk(fpath, fv, oldFv, fp.plan, child)
+		}
+		if r.done() {
+			return
+		}
+	}
+	if r.fields == nil && !sp.fieldsOnly {
+		r.validateStruct(path, v, sp, embedded)
+	}
+}
+
+// validateStruct runs the struct level validations of v, a struct: the
+// StructFuncs registered for its type followed by its GatorValidate
+// method or, unless it is the root struct, its Validate method.  The
+// methods of embedded structs are promoted to the struct embedding them,
+// so they aren't called separately.
+func (r *planRun) validateStruct(path Path, v reflect.Value, sp *structPlan, embedded bool) {
+	if !v.CanInterface() {
+		return
+	}
+	for _, f := range sp.structFuncs {
+		r.addStructError(path, safeCall(v.Type(), func() error {
+			return f(r.ctx, v.Interface())
+		}), false)
+		if r.done() {
+			return
+		}
+	}
+	if embedded {
+		return
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	switch val := v.Addr().Interface().(type) {
+	case StructValidator:
+		r.addStructError(path, safeCall(v.Type(), val.GatorValidate), false)
+	case Validator:
+		if len(path) > 0 {
+			// Validate methods often validate their struct's tags with
+			// NewStruct, which would report the fields' failures again
+			r.addStructError(path, safeCall(v.Type(), val.Validate), true)
+		}
+	}
+}
+
+// safeCall calls f, a struct level validation of a value of type t,
+// and returns its error or the panic it raises as an error.
+func safeCall(t reflect.Type, f func() error) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("gator: struct level validation of %s panicked - %v", t, p)
+		}
+	}()
+	return f()
+}
+
+// addStructError records err, the result of a struct level validation
+// of the struct at path.  The paths of the FieldErrors it holds are
+// made relative to the root struct and other errors are reported for
+// the struct itself.  If dedup is set, FieldErrors for tokens that
+// already failed at the same path are dropped.
+func (r *planRun) addStructError(path Path, err error, dedup bool) {
+	var tErrs TagErrors
+	var cErr *ContextError
+	switch {
+	case err == nil:
+	case errors.As(err, &cErr):
+		r.ctxErr = cErr
+	case errors.As(err, &tErrs):
+		r.tagErrs = append(r.tagErrs, tErrs...)
+	default:
+		for _, fErr := range fieldErrors(err) {
+			e := *fErr
+			switch {
+			case len(fErr.Path) > 0:
+				e.Path = append(path[:len(path):len(path)], fErr.Path...)
+			case fErr.Field != "":
+				e.Path = path.field(fErr.Field)
+			default:
+				e.Path = path
+			}
+			e.Field = e.Path.String()
+			if e.Code == "" {
+				e.Code = errorCode(e.Token)
+			}
+			if dedup && r.reported(&e) {
+				continue
+			}
+			r.errs = append(r.errs, &e)
+		}
+	}
+}
+
+// reported reports whether a failure of e's token at e's path has been
+// recorded already.
+func (r *planRun) reported(e *FieldError) bool {
+	if e.Token == "" {
+		return false
+	}
+	for _, fErr := range r.errs {
+		if fErr.Token == e.Token && fErr.Path.String() == e.Path.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// value returns the interface held by v.  Values of unexported fields
+// are only available if the Gator was created WithUnexported.
+func (r *planRun) value(v reflect.Value) (interface{}, bool) {
+	if v.CanInterface() {
+		return v.Interface(), true
+	}
+	if !r.cfg.unexported {
+		return nil, false
+	}
+	c, ok := copyValue(v)
+	if !ok {
+		return nil, false
+	}
+	return c.Interface(), true
+}
+
+// pointee returns the value v points to if v is a non-nil pointer.
+// Nil pointers are returned as is.
+func pointee(v reflect.Value) reflect.Value {
+	for i := 0; i < 32 && v.Kind() == reflect.Ptr && !v.IsNil(); i++ {
+		v = v.Elem()
+	}
+	return v
+}
+
+// walk descends into v looking for structs.  sp is the plan of the
+// struct type held by v if it is known statically.  sel selects the
+// fields of the structs found.  old is walked alongside v when
+// validating a change.
+func (r *planRun) walk(path Path, v, old reflect.Value, sp *structPlan, sel fieldSet) {
+	if !canContainStruct(v.Type()) {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if sp == nil {
+			sp = r.structPlan(v.Type())
+		}
+		r.walkStruct(path, v, old, sp, sel)
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if old.IsValid() && !old.IsNil() && old.Elem().Type() == v.Elem().Type() {
+			old = old.Elem()
+		} else {
+			old = reflect.Value{}
+		}
+		r.walk(path, v.Elem(), old, nil, sel)
+	case reflect.Ptr:
+		if v.IsNil() || !r.enter(v) {
+			return
+		}
+		defer r.leave(v)
+		if old.IsValid() && !old.IsNil() {
+			old = old.Elem()
+		} else {
+			old = reflect.Value{}
+		}
+		r.walk(path, v.Elem(), old, sp, sel)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice {
+			if v.Len() == 0 || !r.enter(v) {
+				return
+			}
+			defer r.leave(v)
+		}
+		for i := 0; i < v.Len() && !r.done(); i++ {
+			var oldElem reflect.Value
+			if old.IsValid() && i < old.Len() {
+				oldElem = old.Index(i)
+			}
+			r.walk(path.index(i), v.Index(i), oldElem, sp, sel)
+		}
+	case reflect.Map:
+		if v.IsNil() || !r.enter(v) {
+			return
+		}
+		defer r.leave(v)
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		for _, k := range keys {
+			if r.done() {
+				return
+			}
+			var oldElem reflect.Value
+			if old.IsValid() && !old.IsNil() {
+				oldElem = old.MapIndex(k)
+			}
+			r.walk(path.index(mapKey(k)), v.MapIndex(k), oldElem, sp, sel)
+		}
+	}
+}
+
+// structPlan returns the plan for t.  TagErrors of types that aren't
+// reachable from the root plan, whose errors are reported when it is
+// compiled, are recorded on the run once per type.
+func (r *planRun) structPlan(t reflect.Type) *structPlan {
+	sp := structPlanFor(r.cfg, t)
+	if r.tagged == nil {
+		r.tagged = map[*structPlan]bool{}
+		if r.sp != nil {
+			for _, p := range r.sp.reachable() {
+				r.tagged[p] = true
+			}
+		}
+	}
+	if !r.tagged[sp] {
+		r.tagErrs = append(r.tagErrs, sp.tagErrors()...)
+		for _, p := range sp.reachable() {
+			r.tagged[p] = true
+		}
+	}
+	return sp
+}
+
+// enter marks the pointer, map or slice v as being walked.  It returns
+// false if v is already being walked, i.e. it is part of a cycle.
+func (r *planRun) enter(v reflect.Value) bool {
+	if r.visited == nil {
+		r.visited = map[visit]bool{}
+	}
+	key := visitKey(v)
+	if r.visited[key] {
+		return false
+	}
+	r.visited[key] = true
+	return true
+}
+
+// leave marks the pointer, map or slice v as walked.
+func (r *planRun) leave(v reflect.Value) {
+	delete(r.visited, visitKey(v))
+}
+
+func visitKey(v reflect.Value) visit {
+	key := visit{ptr: v.Pointer(), typ: v.Type()}
+	if v.Kind() == reflect.Slice {
+		key.len = v.Len()
+	}
+	return key
+}
+
+// canContainStruct reports whether a value of type t may hold a struct
+// that needs to be walked.
+func canContainStruct(t reflect.Type) bool {
+	t = elemType(t)
+	return t.Kind() == reflect.Struct || t.Kind() == reflect.Interface
+}
+
+// elemType returns the type held by values of type t through pointers,
+// slices, arrays and maps.
+func elemType(t reflect.Type) reflect.Type {
+	for i := 0; i < 32; i++ {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+	// give up on self-referential types such as type T []T
+	return t
+}
+
+// mapKey returns the interface held by k, a map key, or its string form
+// if k was obtained through an unexported field.
+func mapKey(k reflect.Value) interface{} {
+	if k.CanInterface() {
+		return k.Interface()
+	}
+	return fmt.Sprint(k)
+}